@@ -0,0 +1,316 @@
+// Package geojson converts H3 cells, cell sets and polygons to and from GeoJSON
+// (RFC 7946).
+//
+// Positions are written as [longitude, latitude] in degrees, and rings are
+// closed by repeating their first position, as GeoJSON requires. Polygons read
+// from GeoJSON are returned as rings of h3.LatLng without the closing position,
+// matching h3.Cell.Boundary and h3.CellSet.Outline.
+//
+// Geometries that cross the antimeridian are written as they are, without
+// being split.
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+const (
+	// TypeFeature is the type of a GeoJSON feature.
+	TypeFeature = "Feature"
+	// TypeFeatureCollection is the type of a GeoJSON feature collection.
+	TypeFeatureCollection = "FeatureCollection"
+	// TypePolygon is the type of a GeoJSON Polygon geometry.
+	TypePolygon = "Polygon"
+	// TypeMultiPolygon is the type of a GeoJSON MultiPolygon geometry.
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+var (
+	// ErrUnsupportedType is returned for GeoJSON objects of a type this
+	// package can't decode.
+	ErrUnsupportedType = fmt.Errorf("unsupported GeoJSON type")
+	// ErrInvalidGeometry is returned for GeoJSON geometries whose
+	// coordinates are malformed.
+	ErrInvalidGeometry = fmt.Errorf("invalid GeoJSON geometry")
+)
+
+// Geometry is a GeoJSON geometry object. The coordinates are kept in their raw
+// JSON form, and decoded according to the type by Polygon or MultiPolygon.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Feature is a GeoJSON feature object. The id is a string or a number.
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         any            `json:"id,omitempty"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// FeatureCollection is a GeoJSON feature collection object.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeature creates a feature with the given geometry and properties, which
+// may be nil.
+func NewFeature(geometry *Geometry, properties map[string]any) Feature {
+	return Feature{
+		Type:       TypeFeature,
+		Geometry:   geometry,
+		Properties: properties,
+	}
+}
+
+// NewFeatureCollection creates a feature collection of the given features.
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	if features == nil {
+		// GeoJSON requires an array, even if it's empty.
+		features = []Feature{}
+	}
+	return FeatureCollection{
+		Type:     TypeFeatureCollection,
+		Features: features,
+	}
+}
+
+// NewPolygon creates a Polygon geometry from a list of rings: the outer ring
+// followed by any holes.
+func NewPolygon(rings [][]h3.LatLng) (*Geometry, error) {
+	return newGeometry(TypePolygon, encodeRings(rings))
+}
+
+// NewMultiPolygon creates a MultiPolygon geometry from a list of polygons, each
+// a list of rings as for NewPolygon.
+func NewMultiPolygon(polygons [][][]h3.LatLng) (*Geometry, error) {
+	coordinates := make([][][][2]float64, len(polygons))
+	for i, rings := range polygons {
+		coordinates[i] = encodeRings(rings)
+	}
+	return newGeometry(TypeMultiPolygon, coordinates)
+}
+
+func newGeometry(typ string, coordinates any) (*Geometry, error) {
+	raw, err := json.Marshal(coordinates)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s coordinates: %w", typ, err)
+	}
+	return &Geometry{Type: typ, Coordinates: raw}, nil
+}
+
+// CellFeature creates a feature with the boundary of the cell as a Polygon,
+// and the cell's string form as its id.
+func CellFeature(c h3.Cell, properties map[string]any) (Feature, error) {
+	boundary, err := c.Boundary()
+	if err != nil {
+		return Feature{}, fmt.Errorf("error getting boundary for cell %s: %w", c, err)
+	}
+
+	geometry, err := NewPolygon([][]h3.LatLng{boundary})
+	if err != nil {
+		return Feature{}, err
+	}
+
+	feature := NewFeature(geometry, properties)
+	feature.ID = c.String()
+	return feature, nil
+}
+
+// CellSetFeatures creates a feature collection with a feature for each cell in
+// the set, as CellFeature does, ordered by cell. properties is called to get
+// the properties of each cell; it may be nil if there are none.
+func CellSetFeatures(cs h3.CellSet, properties func(c h3.Cell) map[string]any) (FeatureCollection, error) {
+	cells := cs.Cells()
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+	features := make([]Feature, 0, len(cells))
+	for _, c := range cells {
+		var props map[string]any
+		if properties != nil {
+			props = properties(c)
+		}
+
+		feature, err := CellFeature(c, props)
+		if err != nil {
+			return FeatureCollection{}, err
+		}
+		features = append(features, feature)
+	}
+
+	return NewFeatureCollection(features), nil
+}
+
+// OutlineFeature creates a feature with the outline of the cells in the set as
+// a MultiPolygon.
+func OutlineFeature(cs h3.CellSet, properties map[string]any) (Feature, error) {
+	polygons, err := cs.Outline()
+	if err != nil {
+		return Feature{}, fmt.Errorf("error getting outline of cell set: %w", err)
+	}
+
+	geometry, err := NewMultiPolygon(polygons)
+	if err != nil {
+		return Feature{}, err
+	}
+
+	return NewFeature(geometry, properties), nil
+}
+
+// Polygon decodes a Polygon geometry into its rings: the outer ring followed by
+// any holes.
+func (g *Geometry) Polygon() ([][]h3.LatLng, error) {
+	if g.Type != TypePolygon {
+		return nil, fmt.Errorf("%w: expected %s, got %q", ErrUnsupportedType, TypePolygon, g.Type)
+	}
+
+	var coordinates [][][]float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeometry, err)
+	}
+
+	return decodeRings(coordinates)
+}
+
+// MultiPolygon decodes a Polygon or MultiPolygon geometry into a list of
+// polygons, each a list of rings as returned by Polygon.
+func (g *Geometry) MultiPolygon() ([][][]h3.LatLng, error) {
+	if g.Type == TypePolygon {
+		rings, err := g.Polygon()
+		if err != nil {
+			return nil, err
+		}
+		return [][][]h3.LatLng{rings}, nil
+	}
+
+	if g.Type != TypeMultiPolygon {
+		return nil, fmt.Errorf("%w: expected %s or %s, got %q", ErrUnsupportedType, TypePolygon, TypeMultiPolygon, g.Type)
+	}
+
+	var coordinates [][][][]float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeometry, err)
+	}
+
+	polygons := make([][][]h3.LatLng, len(coordinates))
+	for i, rings := range coordinates {
+		var err error
+		polygons[i], err = decodeRings(rings)
+		if err != nil {
+			return nil, fmt.Errorf("polygon %d: %w", i, err)
+		}
+	}
+
+	return polygons, nil
+}
+
+// ParsePolygons reads all the polygons from a GeoJSON document, which can be a
+// Polygon or MultiPolygon geometry, a feature with one of those geometries, or
+// a feature collection of such features. Features without a geometry are
+// skipped.
+func ParsePolygons(data []byte) ([][][]h3.LatLng, error) {
+	var object struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("error decoding GeoJSON: %w", err)
+	}
+
+	switch object.Type {
+	case TypePolygon, TypeMultiPolygon:
+		var geometry Geometry
+		if err := json.Unmarshal(data, &geometry); err != nil {
+			return nil, fmt.Errorf("error decoding GeoJSON geometry: %w", err)
+		}
+		return geometry.MultiPolygon()
+	case TypeFeature:
+		var feature Feature
+		if err := json.Unmarshal(data, &feature); err != nil {
+			return nil, fmt.Errorf("error decoding GeoJSON feature: %w", err)
+		}
+		return featurePolygons(nil, feature)
+	case TypeFeatureCollection:
+		var collection FeatureCollection
+		if err := json.Unmarshal(data, &collection); err != nil {
+			return nil, fmt.Errorf("error decoding GeoJSON feature collection: %w", err)
+		}
+
+		var polygons [][][]h3.LatLng
+		for i, feature := range collection.Features {
+			var err error
+			polygons, err = featurePolygons(polygons, feature)
+			if err != nil {
+				return nil, fmt.Errorf("feature %d: %w", i, err)
+			}
+		}
+		return polygons, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, object.Type)
+	}
+}
+
+// featurePolygons appends the polygons of the feature's geometry to polygons.
+func featurePolygons(polygons [][][]h3.LatLng, feature Feature) ([][][]h3.LatLng, error) {
+	if feature.Geometry == nil {
+		return polygons, nil
+	}
+
+	more, err := feature.Geometry.MultiPolygon()
+	if err != nil {
+		return nil, err
+	}
+	return append(polygons, more...), nil
+}
+
+// encodeRings converts rings to GeoJSON positions, closing each ring.
+func encodeRings(rings [][]h3.LatLng) [][][2]float64 {
+	out := make([][][2]float64, len(rings))
+	for i, ring := range rings {
+		positions := make([][2]float64, 0, len(ring)+1)
+		for _, ll := range ring {
+			positions = append(positions, [2]float64{ll.LongitudeDegrees(), ll.LatitudeDegrees()})
+		}
+		if len(ring) > 0 {
+			positions = append(positions, positions[0])
+		}
+		out[i] = positions
+	}
+	return out
+}
+
+// decodeRings converts GeoJSON positions to rings, dropping the closing
+// position of each ring. Unclosed rings are accepted as well.
+func decodeRings(coordinates [][][]float64) ([][]h3.LatLng, error) {
+	if len(coordinates) == 0 {
+		return nil, fmt.Errorf("%w: polygon has no rings", ErrInvalidGeometry)
+	}
+
+	rings := make([][]h3.LatLng, len(coordinates))
+	for i, positions := range coordinates {
+		ring := make([]h3.LatLng, 0, len(positions))
+		for _, position := range positions {
+			// Any altitude is ignored.
+			if len(position) < 2 {
+				return nil, fmt.Errorf("%w: position has %d values", ErrInvalidGeometry, len(position))
+			}
+			ring = append(ring, h3.NewLatLng(position[1], position[0]))
+		}
+
+		if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+			ring = ring[:len(ring)-1]
+		}
+		if len(ring) < 3 {
+			return nil, fmt.Errorf("%w: ring %d has %d distinct positions", ErrInvalidGeometry, i, len(ring))
+		}
+
+		rings[i] = ring
+	}
+
+	return rings, nil
+}
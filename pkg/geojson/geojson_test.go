@@ -0,0 +1,219 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+func TestCellFeature(t *testing.T) {
+	c := h3.Cell(0x85283473fffffff)
+	feature, err := CellFeature(c, map[string]any{"count": 3})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(feature)
+	assert.NoError(t, err)
+
+	var decoded struct {
+		Type     string `json:"type"`
+		ID       string `json:"id"`
+		Geometry struct {
+			Type        string         `json:"type"`
+			Coordinates [][][2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "Feature", decoded.Type)
+	assert.Equal(t, "85283473fffffff", decoded.ID)
+	assert.Equal(t, "Polygon", decoded.Geometry.Type)
+	assert.Equal(t, map[string]any{"count": 3.0}, decoded.Properties)
+
+	// The ring is closed, and positions are [lng, lat] in degrees.
+	ring := decoded.Geometry.Coordinates[0]
+	assert.Len(t, ring, 7)
+	assert.Equal(t, ring[0], ring[6])
+	assert.InDelta(t, -121.91508032705622, ring[0][0], 1e-9)
+	assert.InDelta(t, 37.271355866731895, ring[0][1], 1e-9)
+
+	// Reading it back gives the cell boundary.
+	boundary, err := c.Boundary()
+	assert.NoError(t, err)
+	polygons, err := ParsePolygons(data)
+	assert.NoError(t, err)
+	assert.Len(t, polygons, 1)
+	assert.Len(t, polygons[0], 1)
+	assert.Len(t, polygons[0][0], len(boundary))
+	for i, ll := range boundary {
+		assert.InDelta(t, ll.Latitude(), polygons[0][0][i].Latitude(), 1e-12)
+		assert.InDelta(t, ll.Longitude(), polygons[0][0][i].Longitude(), 1e-12)
+	}
+}
+
+func TestCellSetFeatures(t *testing.T) {
+	cs := h3.CellSet{0x872830828ffffff: {}, 0x87283082affffff: {}, 0x872830958ffffff: {}}
+
+	t.Run("with properties", func(t *testing.T) {
+		collection, err := CellSetFeatures(cs, func(c h3.Cell) map[string]any {
+			return map[string]any{"res": c.Resolution()}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "FeatureCollection", collection.Type)
+		assert.Len(t, collection.Features, 3)
+		assert.Equal(t, "872830828ffffff", collection.Features[0].ID)
+		assert.Equal(t, "87283082affffff", collection.Features[1].ID)
+		assert.Equal(t, "872830958ffffff", collection.Features[2].ID)
+		assert.Equal(t, map[string]any{"res": 7}, collection.Features[0].Properties)
+
+		data, err := json.Marshal(collection)
+		assert.NoError(t, err)
+		polygons, err := ParsePolygons(data)
+		assert.NoError(t, err)
+		assert.Len(t, polygons, 3)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		collection, err := CellSetFeatures(h3.CellSet{}, nil)
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(collection)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"type": "FeatureCollection", "features": []}`, string(data))
+	})
+}
+
+func TestOutlineFeature(t *testing.T) {
+	disk, err := h3.CellSet{0x872830828ffffff: {}}.GridDisk(2)
+	assert.NoError(t, err)
+	delete(disk, 0x872830828ffffff)
+
+	feature, err := OutlineFeature(disk, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "MultiPolygon", feature.Geometry.Type)
+
+	want, err := disk.Outline()
+	assert.NoError(t, err)
+	got, err := feature.Geometry.MultiPolygon()
+	assert.NoError(t, err)
+	assert.Len(t, got, len(want))
+	assert.Len(t, got[0], 2)
+	assert.Len(t, got[0][0], len(want[0][0]))
+	assert.Len(t, got[0][1], len(want[0][1]))
+}
+
+func TestParsePolygons(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    [][][]h3.LatLng
+		wantErr error
+	}{
+		{
+			name: "polygon with hole",
+			data: `{"type": "Polygon", "coordinates": [
+				[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+				[[2, 2], [2, 8], [8, 8], [8, 2], [2, 2]]
+			]}`,
+			want: [][][]h3.LatLng{{
+				{h3.NewLatLng(0, 0), h3.NewLatLng(0, 10), h3.NewLatLng(10, 10), h3.NewLatLng(10, 0)},
+				{h3.NewLatLng(2, 2), h3.NewLatLng(8, 2), h3.NewLatLng(8, 8), h3.NewLatLng(2, 8)},
+			}},
+		},
+		{
+			name: "multipolygon with altitudes",
+			data: `{"type": "MultiPolygon", "coordinates": [
+				[[[0, 0, 5], [1, 0, 5], [1, 1, 5], [0, 0, 5]]],
+				[[[5, 5], [6, 5], [6, 6], [5, 5]]]
+			]}`,
+			want: [][][]h3.LatLng{
+				{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}},
+				{{h3.NewLatLng(5, 5), h3.NewLatLng(5, 6), h3.NewLatLng(6, 6)}},
+			},
+		},
+		{
+			name: "unclosed ring",
+			data: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1]]]}`,
+			want: [][][]h3.LatLng{{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}}},
+		},
+		{
+			name: "feature",
+			data: `{"type": "Feature", "id": 7, "properties": null, "geometry":
+				{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}}`,
+			want: [][][]h3.LatLng{{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}}},
+		},
+		{
+			name: "feature collection skips empty geometries",
+			data: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {}, "geometry": null},
+				{"type": "Feature", "properties": {}, "geometry":
+					{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}}
+			]}`,
+			want: [][][]h3.LatLng{{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}}},
+		},
+		{
+			name:    "unsupported geometry",
+			data:    `{"type": "Point", "coordinates": [0, 0]}`,
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name: "unsupported geometry in feature",
+			data: `{"type": "Feature", "properties": {}, "geometry":
+				{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}`,
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "degenerate ring",
+			data:    `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [0, 0]]]}`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "short position",
+			data:    `{"type": "Polygon", "coordinates": [[[0], [1, 0], [1, 1], [0, 0]]]}`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "no rings",
+			data:    `{"type": "Polygon", "coordinates": []}`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "wrong nesting",
+			data:    `{"type": "MultiPolygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`,
+			wantErr: ErrInvalidGeometry,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolygons([]byte(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := ParsePolygons([]byte(`{"type": `))
+		assert.Error(t, err)
+	})
+}
+
+func TestGeometry_Polygon(t *testing.T) {
+	rings := [][]h3.LatLng{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}}
+	geometry, err := NewPolygon(rings)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[[[0, 0], [1, 0], [1, 1], [0, 0]]]`, string(geometry.Coordinates))
+
+	got, err := geometry.Polygon()
+	assert.NoError(t, err)
+	assert.Equal(t, rings, got)
+
+	multi, err := NewMultiPolygon([][][]h3.LatLng{rings})
+	assert.NoError(t, err)
+	_, err = multi.Polygon()
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
@@ -0,0 +1,125 @@
+package h3
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// CellStats summarizes the weights of the points binned into a cell.
+type CellStats struct {
+	Count int64
+	Sum   float64
+	Min   float64
+	Max   float64
+}
+
+// Mean returns the mean weight of the points, or NaN if there are none.
+func (s CellStats) Mean() float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// add returns the stats with a point of the given weight added.
+func (s CellStats) add(weight float64) CellStats {
+	if s.Count == 0 {
+		return CellStats{Count: 1, Sum: weight, Min: weight, Max: weight}
+	}
+	return CellStats{
+		Count: s.Count + 1,
+		Sum:   s.Sum + weight,
+		Min:   math.Min(s.Min, weight),
+		Max:   math.Max(s.Max, weight),
+	}
+}
+
+// mergeCellStats returns the stats of the union of the points of the given
+// stats.
+func mergeCellStats(stats []CellStats) CellStats {
+	var merged CellStats
+	for _, s := range stats {
+		if s.Count == 0 {
+			continue
+		}
+		if merged.Count == 0 {
+			merged = s
+			continue
+		}
+		merged.Count += s.Count
+		merged.Sum += s.Sum
+		merged.Min = math.Min(merged.Min, s.Min)
+		merged.Max = math.Max(merged.Max, s.Max)
+	}
+	return merged
+}
+
+// Aggregator bins weighted points into the cells containing them at a fixed
+// resolution and keeps stats of the weights in each cell. It is safe for
+// concurrent use.
+type Aggregator struct {
+	res   int
+	mu    sync.Mutex
+	stats CellMap[CellStats]
+}
+
+// NewAggregator returns an empty aggregator binning points at the given
+// resolution.
+func NewAggregator(res int) (*Aggregator, error) {
+	if res < 0 || res > MAX_H3_RES {
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+	return &Aggregator{res: res, stats: make(CellMap[CellStats])}, nil
+}
+
+// Resolution returns the resolution points are binned at.
+func (a *Aggregator) Resolution() int {
+	return a.res
+}
+
+// Add bins a point with the given weight.
+func (a *Aggregator) Add(ll LatLng, weight float64) error {
+	c, err := NewCellFromLatLng(ll, a.res)
+	if err != nil {
+		return fmt.Errorf("error binning point %v: %w", ll, err)
+	}
+
+	a.mu.Lock()
+	a.stats[c] = a.stats[c].add(weight)
+	a.mu.Unlock()
+	return nil
+}
+
+// CellMap returns a copy of the stats of the cells points were binned into.
+func (a *Aggregator) CellMap() CellMap[CellStats] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cm := make(CellMap[CellStats], len(a.stats))
+	for c, s := range a.stats {
+		cm[c] = s
+	}
+	return cm
+}
+
+// Pyramid returns the stats of the binned points at each resolution from
+// minRes to the aggregator's resolution, indexed by resolution minus minRes.
+// The coarser levels are rolled up from the binned cells, so the stats at every
+// level cover all the points.
+func (a *Aggregator) Pyramid(minRes int) ([]CellMap[CellStats], error) {
+	if minRes < 0 || minRes > a.res {
+		return nil, fmt.Errorf("resolution %d out of range: %w", minRes, ErrInvalidArgument)
+	}
+
+	levels := make([]CellMap[CellStats], a.res-minRes+1)
+	levels[len(levels)-1] = a.CellMap()
+	for i := len(levels) - 2; i >= 0; i-- {
+		level, err := levels[i+1].Rollup(minRes+i, mergeCellStats)
+		if err != nil {
+			return nil, err
+		}
+		levels[i] = level
+	}
+	return levels, nil
+}
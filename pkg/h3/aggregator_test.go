@@ -0,0 +1,91 @@
+package h3
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellStats(t *testing.T) {
+	var s CellStats
+	assert.True(t, math.IsNaN(s.Mean()))
+
+	s = s.add(2).add(-1).add(5)
+	assert.Equal(t, CellStats{Count: 3, Sum: 6, Min: -1, Max: 5}, s)
+	assert.Equal(t, 2.0, s.Mean())
+
+	merged := mergeCellStats([]CellStats{{}, s, {Count: 1, Sum: 7, Min: 7, Max: 7}})
+	assert.Equal(t, CellStats{Count: 4, Sum: 13, Min: -1, Max: 7}, merged)
+}
+
+func TestAggregator(t *testing.T) {
+	agg, err := NewAggregator(9)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, agg.Resolution())
+
+	points := []LatLng{
+		NewLatLng(37.7749, -122.4194),
+		NewLatLng(37.7750, -122.4195),
+		NewLatLng(37.8044, -122.2712),
+		NewLatLng(40.7128, -74.0060),
+	}
+
+	// Every point is added once per worker, with the worker's weight.
+	const workers = 8
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for _, p := range points {
+				assert.NoError(t, agg.Add(p, float64(w)))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	cm := agg.CellMap()
+	for _, p := range points {
+		c, err := NewCellFromLatLng(p, 9)
+		assert.NoError(t, err)
+		assert.True(t, cm.Contains(c))
+		assert.Equal(t, 0.0, cm[c].Min)
+		assert.Equal(t, float64(workers-1), cm[c].Max)
+		assert.Equal(t, float64(workers-1)/2, cm[c].Mean())
+	}
+
+	// The returned map is a copy.
+	for c := range cm {
+		delete(cm, c)
+	}
+	assert.NotEmpty(t, agg.CellMap())
+
+	pyramid, err := agg.Pyramid(5)
+	assert.NoError(t, err)
+	assert.Len(t, pyramid, 5)
+	assert.Equal(t, agg.CellMap(), pyramid[4])
+	for i, level := range pyramid {
+		res, err := level.Resolution()
+		assert.NoError(t, err)
+		assert.Equal(t, 5+i, res)
+
+		// Every level accounts for all the points.
+		stats := make([]CellStats, 0, len(level))
+		for _, s := range level {
+			stats = append(stats, s)
+		}
+		want := CellStats{Count: int64(len(points) * workers), Sum: float64(len(points) * workers * (workers - 1) / 2), Max: workers - 1}
+		assert.Equal(t, want, mergeCellStats(stats))
+	}
+
+	_, err = agg.Pyramid(10)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = agg.Pyramid(-1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	assert.ErrorIs(t, agg.Add(NewLatLng(math.Inf(1), 0), 1), ErrInvalidArgument)
+	_, err = NewAggregator(MAX_H3_RES + 1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,48 @@
+package h3
+
+import "math"
+
+// AreaKm2 returns the area of the cell in square kilometers.
+func (c Cell) AreaKm2() (float64, error) {
+	area, err := c.areaRads2()
+	if err != nil {
+		return 0, err
+	}
+	return area * EARTH_RADIUS_KM * EARTH_RADIUS_KM, nil
+}
+
+// areaRads2 returns the area of the cell on the unit sphere, as the sum of the
+// spherical triangles between its center and each of its edges.
+func (c Cell) areaRads2() (float64, error) {
+	if !c.Valid() {
+		return 0, ErrInvalidArgument
+	}
+
+	center, err := c.LatLng()
+	if err != nil {
+		return 0, err
+	}
+	boundary, err := c.Boundary()
+	if err != nil {
+		return 0, err
+	}
+
+	area := 0.0
+	for i, a := range boundary {
+		b := boundary[(i+1)%len(boundary)]
+		area += triangleArea(a, b, center)
+	}
+	return area, nil
+}
+
+// triangleArea returns the area of the spherical triangle with the given
+// vertices on the unit sphere, using L'Huilier's theorem.
+func triangleArea(a, b, c LatLng) float64 {
+	ab := a.greatCircleDistanceRads(b)
+	bc := b.greatCircleDistanceRads(c)
+	ca := c.greatCircleDistanceRads(a)
+
+	s := (ab + bc + ca) / 2
+	t := math.Tan(s/2) * math.Tan((s-ab)/2) * math.Tan((s-bc)/2) * math.Tan((s-ca)/2)
+	return 4 * math.Atan(math.Sqrt(math.Max(t, 0)))
+}
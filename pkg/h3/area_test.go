@@ -0,0 +1,33 @@
+package h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCell_AreaKm2(t *testing.T) {
+	// The resolution 0 cells tile the sphere.
+	total := 0.0
+	for bc := baseCell(0); bc < NUM_BASE_CELLS; bc++ {
+		area, err := newCell(0, bc, CENTER_DIGIT).AreaKm2()
+		assert.NoError(t, err)
+		total += area
+	}
+	assert.InEpsilon(t, 4*math.Pi*EARTH_RADIUS_KM*EARTH_RADIUS_KM, total, 1e-9)
+
+	// The average hexagon at resolution 5 is about 253 km².
+	area, err := Cell(0x85283473fffffff).AreaKm2()
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 253, area, 0.2)
+
+	hexagon, err := Cell(0x820817fffffffff).AreaKm2()
+	assert.NoError(t, err)
+	pentagon, err := Cell(0x820807fffffffff).AreaKm2()
+	assert.NoError(t, err)
+	assert.Less(t, pentagon, hexagon)
+
+	_, err = Cell(0).AreaKm2()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -1,6 +1,9 @@
 package h3
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 type bbox struct {
 	north, south, east, west float64
@@ -169,3 +172,279 @@ func bboxNormalization(a bbox, b bbox) (longitudeNormalization, longitudeNormali
 
 	return aNormalization, bNormalization
 }
+
+// cellOutline is the boundary of a cell in the plane of longitude and
+// latitude, relative to the frame of a bounding box.
+type cellOutline struct {
+	points [][2]float64
+	// polar is whether the cell contains a pole, in which case its boundary
+	// doesn't enclose it in the plane, and only its latitude range is
+	// meaningful.
+	polar bool
+	south float64
+	north float64
+	// center is the center of the cell.
+	center LatLng
+}
+
+// cellOutline returns the boundary of the cell in the frame of the bounding
+// box.
+func (b bbox) cellOutline(c Cell) (cellOutline, error) {
+	return newCellOutline(c, b.center().Longitude())
+}
+
+// newCellOutline returns the boundary of the cell with longitudes relative to
+// refLng. The boundary is unwrapped around the cell's center, so that cells
+// crossing the antimeridian stay in one piece.
+func newCellOutline(c Cell, refLng float64) (cellOutline, error) {
+	boundary, err := c.Boundary()
+	if err != nil {
+		return cellOutline{}, err
+	}
+	center, err := c.LatLng()
+	if err != nil {
+		return cellOutline{}, err
+	}
+
+	out := cellOutline{
+		points: make([][2]float64, len(boundary)),
+		south:  M_PI_2,
+		north:  -M_PI_2,
+		center: center,
+	}
+	centerLng := constrainLng(center.Longitude() - refLng)
+	for i, v := range boundary {
+		out.points[i] = [2]float64{centerLng + constrainLng(v.Longitude()-center.Longitude()), v.Latitude()}
+		out.south = math.Min(out.south, v.Latitude())
+		out.north = math.Max(out.north, v.Latitude())
+	}
+
+	// The boundary of a cell containing a pole winds all the way around it.
+	winding := 0.0
+	for i, v := range boundary {
+		winding += constrainLng(boundary[(i+1)%len(boundary)].Longitude() - v.Longitude())
+	}
+	if math.Abs(winding) > math.Pi {
+		out.polar = true
+		if center.Latitude() > 0 {
+			out.north = M_PI_2
+		} else {
+			out.south = -M_PI_2
+		}
+	}
+
+	return out, nil
+}
+
+// overlapsCell returns whether any part of the cell is inside the bounding
+// box. Cell edges are treated as straight lines in the plane of longitude and
+// latitude.
+func (b bbox) overlapsCell(c Cell) (bool, error) {
+	outline, err := b.cellOutline(c)
+	if err != nil {
+		return false, err
+	}
+	return b.overlapsOutline(outline), nil
+}
+
+// capRelation returns whether the spherical cap with the center and radius in
+// radians may intersect the bounding box, and whether the box certainly
+// contains it.
+func (b bbox) capRelation(center LatLng, radius float64) (bool, bool) {
+	outline := newCapOutline(center, radius, b.center().Longitude())
+	return b.overlapsOutline(outline), b.containsOutline(outline)
+}
+
+// cover returns the cells at the resolution that overlap the bounding box.
+func (b bbox) cover(res int) (CellSet, error) {
+	return b.coverCells(res, CONTAINMENT_OVERLAPPING)
+}
+
+func (b bbox) overlapsOutline(outline cellOutline) bool {
+	halfWidth := b.widthRads() / 2
+	if outline.polar {
+		// A polar cell covers every longitude near its pole.
+		return outline.north >= b.south && outline.south <= b.north
+	}
+
+	// A vertex of the cell is in the box.
+	for _, p := range outline.points {
+		if b.containsLocalPoint(p) {
+			return true
+		}
+	}
+
+	// A corner of the box is in the cell.
+	corners := [4][2]float64{
+		{-halfWidth, b.south},
+		{halfWidth, b.south},
+		{halfWidth, b.north},
+		{-halfWidth, b.north},
+	}
+	for _, corner := range corners {
+		if pointInRing(outline.points, corner) {
+			return true
+		}
+	}
+
+	// An edge of the cell crosses an edge of the box.
+	for i, p := range outline.points {
+		q := outline.points[(i+1)%len(outline.points)]
+		for j, corner := range corners {
+			if segmentsIntersect(p, q, corner, corners[(j+1)%4]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// containsOutline returns whether the whole cell is inside the bounding box.
+func (b bbox) containsOutline(outline cellOutline) bool {
+	if outline.polar {
+		// Only a box around the whole world can contain a polar cell.
+		return b.widthRads() >= M_2PI && outline.north <= b.north && outline.south >= b.south
+	}
+
+	for _, p := range outline.points {
+		if !b.containsLocalPoint(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsLocalPoint returns whether a point, with its longitude in the frame
+// of the bounding box, is inside the box.
+func (b bbox) containsLocalPoint(p [2]float64) bool {
+	halfWidth := b.widthRads() / 2
+	return p[0] >= -halfWidth && p[0] <= halfWidth && p[1] >= b.south && p[1] <= b.north
+}
+
+// ContainmentMode determines which cells count as covered by an area, when
+// converting an area to cells.
+type ContainmentMode int
+
+const (
+	// CONTAINMENT_CENTER covers the cells whose centers are in the area. Each
+	// point in the area is in exactly one cell, so areas that tile the world
+	// map each cell to exactly one of them.
+	CONTAINMENT_CENTER = ContainmentMode(0)
+	// CONTAINMENT_FULL covers the cells that are entirely within the area.
+	CONTAINMENT_FULL = ContainmentMode(1)
+	// CONTAINMENT_OVERLAPPING covers the cells that overlap the area at all,
+	// so that the cells cover every point in it.
+	CONTAINMENT_OVERLAPPING = ContainmentMode(2)
+)
+
+// coverCells returns the cells at the given resolution that the bounding box
+// covers, according to the containment mode. It flood fills outwards from the
+// cell at the center of the box through the cells that overlap the box, which
+// works because those cells are connected.
+func (b bbox) coverCells(res int, mode ContainmentMode) (CellSet, error) {
+	if mode < CONTAINMENT_CENTER || mode > CONTAINMENT_OVERLAPPING {
+		return nil, fmt.Errorf("unknown containment mode %d: %w", mode, ErrInvalidArgument)
+	}
+
+	start, err := NewCellFromLatLng(b.center(), res)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(CellSet)
+	visited := CellSet{start: {}}
+	queue := []Cell{start}
+	var neighbors []Cell
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		outline, err := b.cellOutline(c)
+		if err != nil {
+			return nil, fmt.Errorf("error getting outline of cell %s: %w", c, err)
+		}
+		if !b.overlapsOutline(outline) {
+			continue
+		}
+
+		switch mode {
+		case CONTAINMENT_CENTER:
+			if b.containsPoint(outline.center) {
+				out.Add(c)
+			}
+		case CONTAINMENT_FULL:
+			if b.containsOutline(outline) {
+				out.Add(c)
+			}
+		default:
+			out.Add(c)
+		}
+
+		neighbors, err = c.appendNeighbors(neighbors[:0])
+		if err != nil {
+			return nil, fmt.Errorf("error getting neighbors for cell %s: %w", c, err)
+		}
+		for _, n := range neighbors {
+			if !visited.Contains(n) {
+				visited.Add(n)
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// newBboxFromCell returns the bounding box of the cell. Cells containing a pole
+// span all longitudes.
+func newBboxFromCell(c Cell) (bbox, error) {
+	// Work in a frame centered on the prime meridian; the longitudes of the
+	// outline are then unwrapped around the cell's center.
+	outline, err := bbox{north: M_PI_2, south: -M_PI_2, east: math.Pi, west: -math.Pi}.cellOutline(c)
+	if err != nil {
+		return bbox{}, err
+	}
+
+	b := bbox{north: outline.north, south: outline.south, east: math.Pi, west: -math.Pi}
+	if outline.polar {
+		return b, nil
+	}
+
+	west, east := math.Inf(1), math.Inf(-1)
+	for _, p := range outline.points {
+		west = math.Min(west, p[0])
+		east = math.Max(east, p[0])
+	}
+	b.west = constrainLng(west)
+	b.east = constrainLng(east)
+	return b, nil
+}
+
+// pointInRing returns whether the point is inside the ring, using a ray cast.
+func pointInRing(ring [][2]float64, p [2]float64) bool {
+	contains := false
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		if (a[1] > p[1]) == (b[1] > p[1]) {
+			continue
+		}
+		x := a[0] + (p[1]-a[1])/(b[1]-a[1])*(b[0]-a[0])
+		if x > p[0] {
+			contains = !contains
+		}
+	}
+	return contains
+}
+
+// segmentsIntersect returns whether the segments p0-p1 and p2-p3 intersect.
+func segmentsIntersect(p0, p1, p2, p3 [2]float64) bool {
+	cross := func(o, a, b [2]float64) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+	d1 := cross(p2, p3, p0)
+	d2 := cross(p2, p3, p1)
+	d3 := cross(p0, p1, p2)
+	d4 := cross(p0, p1, p3)
+	return ((d1 > 0) != (d2 > 0)) && ((d3 > 0) != (d4 > 0))
+}
@@ -575,3 +575,114 @@ func Test_bbox_widthRads(t *testing.T) {
 		})
 	}
 }
+
+func Test_bbox_overlapsCell(t *testing.T) {
+	c, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 5)
+	assert.NoError(t, err)
+	center, err := c.LatLng()
+	assert.NoError(t, err)
+	antimeridian, err := NewCellFromLatLng(NewLatLng(0, 180), 0)
+	assert.NoError(t, err)
+
+	around := func(ll LatLng, halfSize float64) bbox {
+		return bbox{
+			north: ll.Latitude() + halfSize,
+			south: ll.Latitude() - halfSize,
+			east:  constrainLng(ll.Longitude() + halfSize),
+			west:  constrainLng(ll.Longitude() - halfSize),
+		}
+	}
+
+	tests := []struct {
+		name string
+		b    bbox
+		c    Cell
+		want bool
+	}{
+		{"box around the cell", around(center, 0.1), c, true},
+		{"box inside the cell", around(center, 0.0001), c, true},
+		{"box far away", around(NewLatLng(-33, 151), 0.1), c, false},
+		{"box across an edge", around(LatLng{center.Latitude(), center.Longitude() + 0.0015}, 0.0005), c, true},
+		{"box just past the corner", around(LatLng{center.Latitude() + 0.003, center.Longitude()}, 0.0001), c, false},
+		{"polar cell", bbox{north: deg2rad(89.5), south: deg2rad(89), east: deg2rad(-170), west: deg2rad(-171)}, mustCellFromString("8001fffffffffff"), true},
+		{"box across the antimeridian", bbox{north: 0.01, south: -0.01, east: -3.14, west: 3.14}, antimeridian, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.overlapsCell(tt.c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_bbox_coverCells(t *testing.T) {
+	b := bbox{north: deg2rad(37.9), south: deg2rad(37.6), east: deg2rad(-122.2), west: deg2rad(-122.6)}
+	const res = 6
+
+	overlapping, err := b.coverCells(res, CONTAINMENT_OVERLAPPING)
+	assert.NoError(t, err)
+	centers, err := b.coverCells(res, CONTAINMENT_CENTER)
+	assert.NoError(t, err)
+	full, err := b.coverCells(res, CONTAINMENT_FULL)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, full)
+	assert.Less(t, len(full), len(centers))
+	assert.Less(t, len(centers), len(overlapping))
+	for c := range full {
+		assert.True(t, centers.Contains(c), "fully contained cell %s has its center outside", c)
+	}
+	for c := range centers {
+		assert.True(t, overlapping.Contains(c), "cell %s with its center inside doesn't overlap", c)
+		center, err := c.LatLng()
+		assert.NoError(t, err)
+		assert.True(t, b.containsPoint(center))
+	}
+	for c := range full {
+		boundary, err := c.Boundary()
+		assert.NoError(t, err)
+		for _, v := range boundary {
+			assert.True(t, b.containsPoint(v), "vertex %v of cell %s is outside", v, c)
+		}
+	}
+
+	_, err = b.coverCells(res, ContainmentMode(-1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = b.coverCells(res, CONTAINMENT_OVERLAPPING+1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func Test_newBboxFromCell(t *testing.T) {
+	t.Run("cell", func(t *testing.T) {
+		c := mustCellFromString("85283473fffffff")
+		b, err := newBboxFromCell(c)
+		assert.NoError(t, err)
+		assert.False(t, b.isTransmeridian())
+
+		boundary, err := c.Boundary()
+		assert.NoError(t, err)
+		north := -M_PI_2
+		for _, v := range boundary {
+			assert.True(t, b.containsPoint(v))
+			north = math.Max(north, v.Latitude())
+		}
+		assert.Equal(t, north, b.north)
+	})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		c, err := NewCellFromLatLng(NewLatLng(0, 180), 2)
+		assert.NoError(t, err)
+		b, err := newBboxFromCell(c)
+		assert.NoError(t, err)
+		assert.True(t, b.isTransmeridian())
+		assert.Less(t, b.widthRads(), 0.5)
+	})
+
+	t.Run("pole", func(t *testing.T) {
+		b, err := newBboxFromCell(mustCellFromString("8001fffffffffff"))
+		assert.NoError(t, err)
+		assert.Equal(t, M_PI_2, b.north)
+		assert.Equal(t, M_2PI, b.widthRads())
+	})
+}
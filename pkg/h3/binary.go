@@ -0,0 +1,252 @@
+package h3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// The binary format of a cell set is a header followed by the cells in
+// ascending order:
+//
+//	magic    "H3CS"
+//	version  1 byte, CELL_SET_FORMAT_VERSION
+//	res      1 byte, the resolution of every cell, or 0xff for mixed
+//	cells    a uvarint per cell, the difference from the previous cell
+//	end      a zero uvarint
+//
+// When every cell has the same resolution, the mode and resolution bits they
+// share and the unused digits are dropped, leaving the base cell and digits.
+// Differences are offset by one so that a zero can end the stream, which lets
+// cells be written without knowing how many there are.
+const (
+	// CELL_SET_FORMAT_VERSION is the version of the binary cell set format.
+	CELL_SET_FORMAT_VERSION = 1
+	// MIXED_RESOLUTIONS is the resolution of streams of cells with different
+	// resolutions.
+	MIXED_RESOLUTIONS = -1
+
+	cellSetMagic = "H3CS"
+	mixedResByte = 0xff
+)
+
+// CellSetWriter writes cells in the binary cell set format. Cells must be
+// written in ascending order, and the writer closed to end the stream.
+type CellSetWriter struct {
+	w      *bufio.Writer
+	res    int
+	prev   uint64
+	buf    []byte
+	closed bool
+}
+
+// NewCellSetWriter writes the header of a stream of cells to w. res is the
+// resolution of every cell, or MIXED_RESOLUTIONS.
+func NewCellSetWriter(w io.Writer, res int) (*CellSetWriter, error) {
+	header := []byte(cellSetMagic + "\x00\x00")
+	header[4] = CELL_SET_FORMAT_VERSION
+	switch {
+	case res == MIXED_RESOLUTIONS:
+		header[5] = mixedResByte
+	case res >= 0 && res <= MAX_H3_RES:
+		header[5] = byte(res)
+	default:
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	cw := &CellSetWriter{w: bufio.NewWriter(w), res: res}
+	if _, err := cw.w.Write(header); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write writes a cell, which must be greater than the previous one.
+func (cw *CellSetWriter) Write(c Cell) error {
+	if cw.closed {
+		return fmt.Errorf("write to closed cell set writer")
+	}
+	if !c.Valid() {
+		return fmt.Errorf("cell %s is invalid: %w", c, ErrInvalidArgument)
+	}
+	if cw.res != MIXED_RESOLUTIONS && c.Resolution() != cw.res {
+		return fmt.Errorf("cell %s is not at resolution %d: %w", c, cw.res, ErrInvalidArgument)
+	}
+
+	// Offset values by one so that the first cell's difference is never zero.
+	v := cw.value(c) + 1
+	if v <= cw.prev {
+		return fmt.Errorf("cell %s is out of order: %w", c, ErrInvalidArgument)
+	}
+
+	cw.buf = binary.AppendUvarint(cw.buf[:0], v-cw.prev)
+	cw.prev = v
+	_, err := cw.w.Write(cw.buf)
+	return err
+}
+
+// value returns the bits of the cell that are written.
+func (cw *CellSetWriter) value(c Cell) uint64 {
+	if cw.res == MIXED_RESOLUTIONS {
+		return uint64(c)
+	}
+	return (uint64(c) & (1<<H3_RES_OFFSET - 1)) >> unusedDigitBits(cw.res)
+}
+
+// Close ends the stream and flushes it to the underlying writer. It does not
+// close the underlying writer.
+func (cw *CellSetWriter) Close() error {
+	if cw.closed {
+		return nil
+	}
+	cw.closed = true
+	if err := cw.w.WriteByte(0); err != nil {
+		return err
+	}
+	return cw.w.Flush()
+}
+
+// unusedDigitBits returns the number of bits of the digits beyond the
+// resolution.
+func unusedDigitBits(res int) int {
+	return (MAX_H3_RES - res) * H3_PER_DIGIT_OFFSET
+}
+
+// CellSetReader reads cells in the binary cell set format.
+type CellSetReader struct {
+	r    *bufio.Reader
+	res  int
+	prev uint64
+	done bool
+}
+
+// NewCellSetReader reads the header of a stream of cells from r.
+func NewCellSetReader(r io.Reader) (*CellSetReader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(cellSetMagic)+2)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrInvalidEncoding, err)
+	}
+	if string(header[:len(cellSetMagic)]) != cellSetMagic {
+		return nil, fmt.Errorf("%w: bad magic %q", ErrInvalidEncoding, header[:len(cellSetMagic)])
+	}
+	if version := header[len(cellSetMagic)]; version != CELL_SET_FORMAT_VERSION {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, version)
+	}
+
+	res := int(header[len(cellSetMagic)+1])
+	switch {
+	case res == mixedResByte:
+		res = MIXED_RESOLUTIONS
+	case res > MAX_H3_RES:
+		return nil, fmt.Errorf("%w: resolution %d out of range", ErrInvalidEncoding, res)
+	}
+
+	return &CellSetReader{r: br, res: res}, nil
+}
+
+// Resolution returns the resolution of every cell in the stream, or
+// MIXED_RESOLUTIONS.
+func (cr *CellSetReader) Resolution() int {
+	return cr.res
+}
+
+// Read returns the next cell, or io.EOF at the end of the stream.
+func (cr *CellSetReader) Read() (Cell, error) {
+	if cr.done {
+		return 0, io.EOF
+	}
+
+	delta, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	if delta == 0 {
+		cr.done = true
+		return 0, io.EOF
+	}
+
+	v := cr.prev + delta
+	if v < cr.prev {
+		return 0, fmt.Errorf("%w: cell value overflows", ErrInvalidEncoding)
+	}
+	cr.prev = v
+
+	c := Cell(v - 1)
+	if cr.res != MIXED_RESOLUTIONS {
+		shift := unusedDigitBits(cr.res)
+		if v-1 >= 1<<(H3_RES_OFFSET-shift) {
+			return 0, fmt.Errorf("%w: cell value overflows", ErrInvalidEncoding)
+		}
+		c = Cell(uint64(H3_CELL_MODE)<<H3_MODE_OFFSET | uint64(cr.res)<<H3_RES_OFFSET | (v-1)<<shift | (1<<shift - 1))
+	}
+	if !c.Valid() {
+		return 0, fmt.Errorf("%w: invalid cell %s", ErrInvalidEncoding, c)
+	}
+
+	return c, nil
+}
+
+// MarshalBinary encodes the set in the binary cell set format.
+func (cs CellSet) MarshalBinary() ([]byte, error) {
+	res, err := cs.Resolution()
+	if err != nil {
+		res = MIXED_RESOLUTIONS
+	}
+
+	cells := cs.Cells()
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+	var b bytes.Buffer
+	cw, err := NewCellSetWriter(&b, res)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cells {
+		if err := cw.Write(c); err != nil {
+			return nil, err
+		}
+	}
+	if err := cw.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the set with cells decoded from the binary cell set
+// format.
+func (cs *CellSet) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	cr, err := NewCellSetReader(r)
+	if err != nil {
+		return err
+	}
+
+	out := make(CellSet)
+	for {
+		c, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		out.Add(c)
+	}
+
+	// The reader buffers its input, so count what it left unread.
+	if rest := cr.r.Buffered() + r.Len(); rest > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, rest)
+	}
+
+	*cs = out
+	return nil
+}
@@ -0,0 +1,122 @@
+package h3
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellSet_MarshalBinary(t *testing.T) {
+	origin, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 9)
+	assert.NoError(t, err)
+	disk, err := origin.GridDisk(50)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		cs   CellSet
+	}{
+		{"empty", CellSet{}},
+		{"base cells", NewCellSetFromCells([]Cell{mustCellFromString("8001fffffffffff"), mustCellFromString("80f3fffffffffff")})},
+		{"disk", NewCellSetFromCells(disk)},
+		{"pentagon", NewCellSetFromCells([]Cell{mustCellFromString("8009fffffffffff"), mustCellFromString("8109bffffffffff")})},
+		{"mixed resolutions", NewCellSetFromCells([]Cell{origin, mustCellFromString("85283473fffffff"), mustCellFromString("8009fffffffffff")})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.cs.MarshalBinary()
+			assert.NoError(t, err)
+
+			var got CellSet
+			assert.NoError(t, got.UnmarshalBinary(data))
+			assert.Equal(t, tt.cs, got)
+		})
+	}
+
+	t.Run("known encoding", func(t *testing.T) {
+		data, err := NewCellSetFromCells([]Cell{mustCellFromString("8001fffffffffff"), mustCellFromString("8003fffffffffff")}).MarshalBinary()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("H3CS\x01\x00\x01\x01\x00"), data)
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		data, err := NewCellSetFromCells(disk).MarshalBinary()
+		assert.NoError(t, err)
+		asJSON, err := json.Marshal(NewCellSetFromCells(disk).Strings())
+		assert.NoError(t, err)
+		assert.Less(t, len(data)*8, len(asJSON), "%d bytes of binary vs %d of JSON", len(data), len(asJSON))
+	})
+}
+
+func TestCellSet_UnmarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"bad magic", "H3CX\x01\x00\x00"},
+		{"bad version", "H3CS\x02\x00\x00"},
+		{"bad resolution", "H3CS\x01\x10\x00"},
+		{"truncated", "H3CS\x01\x00\x01"},
+		{"truncated varint", "H3CS\x01\x00\x81"},
+		{"trailing bytes", "H3CS\x01\x00\x01\x00\x00"},
+		{"base cell out of range", "H3CS\x01\x00\x7f\x00"},
+		{"invalid mixed cell", "H3CS\x01\xff\x01\x00"},
+		{"pentagon deleted subsequence", "H3CS\x01\x01\x22\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cs CellSet
+			assert.ErrorIs(t, cs.UnmarshalBinary([]byte(tt.data)), ErrInvalidEncoding)
+		})
+	}
+}
+
+func TestCellSetWriter(t *testing.T) {
+	cells := []Cell{mustCellFromString("85283473fffffff"), mustCellFromString("85283477fffffff")}
+
+	t.Run("stream", func(t *testing.T) {
+		var b bytes.Buffer
+		w, err := NewCellSetWriter(&b, 5)
+		assert.NoError(t, err)
+		for _, c := range cells {
+			assert.NoError(t, w.Write(c))
+		}
+		assert.NoError(t, w.Close())
+
+		r, err := NewCellSetReader(&b)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, r.Resolution())
+		var got []Cell
+		for {
+			c, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			got = append(got, c)
+		}
+		assert.Equal(t, cells, got)
+
+		_, err = r.Read()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewCellSetWriter(io.Discard, MAX_H3_RES+1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		w, err := NewCellSetWriter(io.Discard, 5)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, w.Write(mustCellFromString("8009fffffffffff")), ErrInvalidArgument, "wrong resolution")
+		assert.ErrorIs(t, w.Write(Cell(0x7fffffffffffffff)), ErrInvalidArgument, "invalid cell")
+		assert.NoError(t, w.Write(cells[1]))
+		assert.ErrorIs(t, w.Write(cells[0]), ErrInvalidArgument, "out of order")
+		assert.ErrorIs(t, w.Write(cells[1]), ErrInvalidArgument, "duplicate")
+		assert.NoError(t, w.Close())
+		assert.Error(t, w.Write(mustCellFromString("85283476fffffff")))
+	})
+}
@@ -1,11 +1,17 @@
 package h3
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 )
 
 const (
+	// MAX_GRID_DISTANCE_SEARCH is the largest grid distance that
+	// Cell.GridDistance finds by searching the grid, where the cells can't be
+	// placed in one local coordinate space.
+	MAX_GRID_DISTANCE_SEARCH = 1000
+
 	// H3_NUM_BITS is the number of bits in an H3 index.
 	H3_NUM_BITS = 64
 	// H3_MAX_OFFSET is the max resolution digit in an H3 index.
@@ -89,6 +95,91 @@ func NewCellFromLatLng(ll LatLng, res int) (Cell, error) {
 	return cell, nil
 }
 
+// LatLng returns the center point of the cell.
+func (c Cell) LatLng() (LatLng, error) {
+	fijk, err := c.toFaceIjk()
+	if err != nil {
+		return LatLng{}, err
+	}
+
+	return fijk.toLatLng(c.Resolution()), nil
+}
+
+// Boundary returns the vertices of the cell in counter-clockwise order. The
+// ring is not closed, i.e. the first vertex is not repeated at the end.
+//
+// Hexagons have 6 vertices and pentagons 5, but cells whose edges cross an
+// edge of the underlying icosahedron get an extra vertex where they cross it,
+// so that adjacent cells share every vertex on their common edges.
+func (c Cell) Boundary() ([]LatLng, error) {
+	fijk, err := c.toFaceIjk()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.isPentagon() {
+		return fijk.toPentagonBoundary(c.Resolution()), nil
+	}
+	return fijk.toCellBoundary(c.Resolution()), nil
+}
+
+// toFaceIjk converts the cell to the FaceIJK address of its center on the face
+// that contains it.
+func (c Cell) toFaceIjk() (faceIJK, error) {
+	bc := c.BaseCell()
+	if bc < 0 || bc >= NUM_BASE_CELLS {
+		return faceIJK{}, ErrInvalidArgument
+	}
+
+	// adjust for the pentagonal missing sequence; all of sub-sequence 5 needs to
+	// be adjusted (and some of sub-sequence 4 below)
+	h := c
+	if bc.isPentagon() && h.leadingNonZeroDigit() == IK_AXES_DIGIT {
+		h = h.rotate60cw()
+	}
+
+	// start with the "home" face and ijk+ coordinates for the base cell of c
+	fijk, possibleOverage := h.toFaceIjkWithInitializedFijk(baseCellData[bc].homeFijk)
+	if !possibleOverage {
+		// no overage is possible; h lies on this face
+		return fijk, nil
+	}
+
+	// if we're here we have the potential for an "overage"; i.e., it is possible
+	// that c lies on an adjacent face
+	origIJK := fijk.coord
+
+	// if we're in Class III, drop into the next finer Class II grid
+	res := h.Resolution()
+	if isResolutionClassIII(res) {
+		fijk.coord = fijk.coord.downAp7r()
+		res++
+	}
+
+	// adjust for overage if needed; a pentagon base cell with a leading 4 digit
+	// requires special handling
+	pentLeading4 := bc.isPentagon() && h.leadingNonZeroDigit() == I_AXES_DIGIT
+	var over overage
+	fijk, over = fijk.adjustOverageClassII(res, pentLeading4, false)
+	if over != NO_OVERAGE {
+		// if the base cell is a pentagon we have the potential for secondary
+		// overages
+		if bc.isPentagon() {
+			for over != NO_OVERAGE {
+				fijk, over = fijk.adjustOverageClassII(res, false, false)
+			}
+		}
+
+		if res != h.Resolution() {
+			fijk.coord = fijk.coord.upAp7r()
+		}
+	} else if res != h.Resolution() {
+		fijk.coord = origIJK
+	}
+
+	return fijk, nil
+}
+
 func faceIJKToH3(fijk faceIJK, res int) (Cell, error) {
 	// Initialize the new H3 index
 	h := H3_INIT.setMode(H3_CELL_MODE).setResolution(res)
@@ -381,7 +472,7 @@ func (c Cell) neighborRotations(direction Direction, rotations int) (Cell, int,
 		}
 
 		for i := 0; i < newRotations; i++ {
-			current = current.rotate60ccw()
+			current = current.rotatePentagon60ccw()
 		}
 
 		if oldBaseCell != newBaseCell {
@@ -495,12 +586,42 @@ func (c Cell) rotatePentagon60ccw() Cell {
 	return out
 }
 
-// GridDistance returns the number of grid cells between this cell and the other.
-//
-// This function may return an error if the cells are very far apart, if the
-// cells are not at the same resolution, or if the cells are on opposite sides of
-// a pentagon.
+// GridDistance returns the number of grid steps between two cells of the
+// same resolution. Where the cells can't be placed in one local coordinate
+// space, e.g. when the path between them crosses a pentagon's distortion area,
+// the grid is searched from both cells instead, up to a distance of
+// MAX_GRID_DISTANCE_SEARCH. Cells too far apart on the sphere to be that close
+// on the grid fail without a search.
 func (c Cell) GridDistance(other Cell) (int, error) {
+	if c.Resolution() != other.Resolution() {
+		return 0, ErrResolutionMismatch
+	}
+
+	d, err := c.localGridDistance(other)
+	if err == nil {
+		return d, nil
+	}
+	if !c.Valid() || !other.Valid() {
+		return 0, err
+	}
+
+	if c == other {
+		return 0, nil
+	}
+	cells, others := CellSet{c: {}}, CellSet{other: {}}
+	far, err := beyondGridDistanceSearch(cells, others, c.Resolution())
+	if err != nil {
+		return 0, err
+	}
+	if far {
+		return 0, fmt.Errorf("cells are more than %d steps apart: %w", MAX_GRID_DISTANCE_SEARCH, ErrInvalidArgument)
+	}
+	return gridDistanceSearch(cells, cells, others, others, MAX_GRID_DISTANCE_SEARCH)
+}
+
+// localGridDistance returns the grid distance between two cells by placing
+// them in the local coordinate space of the first one.
+func (c Cell) localGridDistance(other Cell) (int, error) {
 	originIjk, err := c.toLocalIJK(c)
 	if err != nil {
 		return 0, err
@@ -594,6 +715,206 @@ func (c Cell) Parent(res int) (Cell, error) {
 	return parent, nil
 }
 
+// CenterChild returns the center child of the cell at the given resolution,
+// i.e. its descendant sharing its center.
+func (c Cell) CenterChild(res int) (Cell, error) {
+	parentRes := c.Resolution()
+
+	if res < 0 || res > MAX_H3_RES {
+		return 0, ErrInvalidArgument
+	} else if res < parentRes {
+		return 0, ErrInvalidArgument
+	} else if res == parentRes {
+		return c, nil
+	}
+
+	child := c.setResolution(res)
+	for r := parentRes + 1; r <= res; r++ {
+		child = child.setIndexDigit(r, CENTER_DIGIT)
+	}
+
+	return child, nil
+}
+
+// ChildPos returns the position of the cell among the descendants of its
+// ancestor at parentRes, in the order ChildPosToCell enumerates them.
+// Positions are dense, from 0 to one less than the number of descendants, so
+// they can index flat arrays of per-child values.
+func (c Cell) ChildPos(parentRes int) (int64, error) {
+	if !c.Valid() {
+		return 0, ErrInvalidArgument
+	}
+	if _, err := c.Parent(parentRes); err != nil {
+		return 0, err
+	}
+
+	childRes := c.Resolution()
+	pos := int64(0)
+	for r := childRes; r > parentRes; r-- {
+		// Children of a pentagon skip the K axes digit, so their subtrees
+		// are shifted down by one and the center subtree is a pentagon's.
+		parent, err := c.Parent(r - 1)
+		if err != nil {
+			return 0, err
+		}
+		pentagon := parent.isPentagon()
+
+		digit := c.getIndexDigit(r)
+		if pentagon && digit > CENTER_DIGIT {
+			digit--
+		}
+		if digit == CENTER_DIGIT {
+			continue
+		}
+
+		hexagons := int64(ipow(7, childRes-r))
+		pos += childCount(pentagon, childRes-r) + hexagons*int64(digit-1)
+	}
+
+	return pos, nil
+}
+
+// ChildPosToCell returns the descendant of parent at childRes with the given
+// position, as returned by Cell.ChildPos.
+func ChildPosToCell(pos int64, parent Cell, childRes int) (Cell, error) {
+	parentRes := parent.Resolution()
+
+	if !parent.Valid() || childRes < parentRes || childRes > MAX_H3_RES {
+		return 0, ErrInvalidArgument
+	}
+	if pos < 0 || pos >= childCount(parent.isPentagon(), childRes-parentRes) {
+		return 0, ErrInvalidArgument
+	}
+
+	child := parent.setResolution(childRes)
+	pentagon := parent.isPentagon()
+	for r := parentRes + 1; r <= childRes; r++ {
+		width := int64(ipow(7, childRes-r))
+
+		if pentagon {
+			// The center subtree of a pentagon comes first, followed by the
+			// hexagon subtrees of digits 2 to 6.
+			center := childCount(true, childRes-r)
+			if pos < center {
+				child = child.setIndexDigit(r, CENTER_DIGIT)
+				continue
+			}
+			pos -= center
+			pentagon = false
+			child = child.setIndexDigit(r, Direction(pos/width)+J_AXES_DIGIT)
+		} else {
+			child = child.setIndexDigit(r, Direction(pos/width))
+		}
+		pos %= width
+	}
+
+	return child, nil
+}
+
+// childCount returns the number of descendants a cell has the given number of
+// resolutions down. Pentagons have five hexagon children and a pentagon child.
+func childCount(pentagon bool, levels int) int64 {
+	n := int64(ipow(7, levels))
+	if pentagon {
+		return 1 + 5*(n-1)/6
+	}
+	return n
+}
+
+// IsDescendantOf returns whether the cell is a descendant of the other cell,
+// i.e. whether its digits start with the other cell's. A cell is a descendant
+// of itself.
+func (c Cell) IsDescendantOf(a Cell) bool {
+	if !c.Valid() || !a.Valid() || a.Resolution() > c.Resolution() {
+		return false
+	}
+
+	parent, err := c.Parent(a.Resolution())
+	return err == nil && parent == a
+}
+
+// Ancestors returns the ancestors of the cell from resolution 0 down to the
+// cell itself, which is last. It returns nil for an invalid cell.
+func (c Cell) Ancestors() []Cell {
+	if !c.Valid() {
+		return nil
+	}
+
+	ancestors := make([]Cell, c.Resolution()+1)
+	for r := range ancestors {
+		// Parents are found by masking digits, which can't fail for a valid
+		// cell.
+		ancestors[r], _ = c.Parent(r)
+	}
+	return ancestors
+}
+
+// LowestCommonAncestor returns the finest cell that all the cells descend
+// from, which is one of the cells if the others descend from it. Cells on
+// different base cells have no common ancestor.
+func LowestCommonAncestor(cells ...Cell) (Cell, error) {
+	if len(cells) == 0 {
+		return 0, fmt.Errorf("no cells: %w", ErrInvalidArgument)
+	}
+
+	first := cells[0]
+	res := MAX_H3_RES
+	for _, c := range cells {
+		if !c.Valid() {
+			return 0, fmt.Errorf("cell %s is invalid: %w", c, ErrInvalidArgument)
+		}
+		if c.BaseCell() != first.BaseCell() {
+			return 0, fmt.Errorf("cells %s and %s: %w", first, c, ErrNoCommonAncestor)
+		}
+		res = min(res, c.Resolution())
+
+		// Shrink the resolution to the digits this cell shares with the first.
+		for r := 1; r <= res; r++ {
+			if c.getIndexDigit(r) != first.getIndexDigit(r) {
+				res = r - 1
+				break
+			}
+		}
+	}
+
+	return first.Parent(res)
+}
+
+// DescendantRange returns the smallest and largest keys of the cell's
+// descendants at resolution 15. Every resolution 15 descendant is within the
+// range, and every resolution 15 cell within it is a descendant, so a sorted
+// store of resolution 15 cells can find the cells inside this one with a
+// single range scan.
+func (c Cell) DescendantRange() (uint64, uint64) {
+	first := c.setResolution(MAX_H3_RES)
+	last := first
+	for r := c.Resolution() + 1; r <= MAX_H3_RES; r++ {
+		first = first.setIndexDigit(r, CENTER_DIGIT)
+		last = last.setIndexDigit(r, IJ_AXES_DIGIT)
+	}
+	return uint64(first), uint64(last)
+}
+
+// nextKey returns the smallest resolution 15 key after k whose digits are all
+// valid, counting through the digits in base 7 and then the base cells. Keys
+// in a pentagon's deleted K axes subsequence are skipped.
+func nextKey(k uint64) uint64 {
+	c := Cell(k)
+	for r := MAX_H3_RES; r >= 1; r-- {
+		if d := c.getIndexDigit(r); d < IJ_AXES_DIGIT {
+			next := c.setIndexDigit(r, d+1)
+			if next.BaseCell().isPentagon() && next.leadingNonZeroDigit() == K_AXES_DIGIT {
+				// The digits before r are all zero, so the subsequence is
+				// the keys with a K axes digit at r.
+				next = next.setIndexDigit(r, J_AXES_DIGIT)
+			}
+			return uint64(next)
+		}
+		c = c.setIndexDigit(r, CENTER_DIGIT)
+	}
+	return uint64(c.setBaseCell(c.BaseCell() + 1))
+}
+
 // rotate60ccw rotates the given digit 60 degrees counter-clockwise and returns the new digit.
 func rotate60ccw(digit Direction) Direction {
 	switch digit {
@@ -0,0 +1,188 @@
+package h3
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MAX_DISAGGREGATE_CELLS is the most cells that CellMap.Disaggregate returns.
+// Coarse cells disaggregated to fine resolutions exceed it.
+const MAX_DISAGGREGATE_CELLS = 1 << 20
+
+// CellMap maps H3 cells to values. It is the valued counterpart of CellSet.
+type CellMap[V any] map[Cell]V
+
+// Cells returns the cells in the map as a list.
+func (cm CellMap[V]) Cells() []Cell {
+	cells := make([]Cell, 0, len(cm))
+	for c := range cm {
+		cells = append(cells, c)
+	}
+	return cells
+}
+
+// CellSet returns the cells in the map as a set.
+func (cm CellMap[V]) CellSet() CellSet {
+	cs := make(CellSet, len(cm))
+	for c := range cm {
+		cs.Add(c)
+	}
+	return cs
+}
+
+// Contains returns whether the map contains the given cell.
+func (cm CellMap[V]) Contains(c Cell) bool {
+	_, ok := cm[c]
+	return ok
+}
+
+// Resolution returns the resolution of the cells in the map. The function
+// will return an error if the map is empty or contains cells of different
+// resolutions.
+func (cm CellMap[V]) Resolution() (int, error) {
+	if len(cm) == 0 {
+		return 0, fmt.Errorf("empty cell map")
+	}
+
+	resolution := -1
+	for c := range cm {
+		if resolution == -1 {
+			resolution = c.Resolution()
+		} else if c.Resolution() != resolution {
+			return 0, fmt.Errorf("cell map contains cells of different resolutions")
+		}
+	}
+
+	return resolution, nil
+}
+
+// Union returns a new map with the cells of both maps. Values of cells in both
+// maps are combined with merge.
+func (cm CellMap[V]) Union(other CellMap[V], merge func(a, b V) V) CellMap[V] {
+	result := make(CellMap[V], len(cm)+len(other))
+	for c, v := range cm {
+		result[c] = v
+	}
+	for c, v := range other {
+		if w, ok := result[c]; ok {
+			result[c] = merge(w, v)
+		} else {
+			result[c] = v
+		}
+	}
+	return result
+}
+
+// Intersects returns whether any cell of the map is in the set.
+func (cm CellMap[V]) Intersects(cs CellSet) bool {
+	for c := range cm {
+		if cs.Contains(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Restrict returns a new map with only the cells that are in the set.
+func (cm CellMap[V]) Restrict(cs CellSet) CellMap[V] {
+	result := make(CellMap[V])
+	for c, v := range cm {
+		if cs.Contains(c) {
+			result[c] = v
+		}
+	}
+	return result
+}
+
+// Subtract returns a new map without the cells that are in the set.
+func (cm CellMap[V]) Subtract(cs CellSet) CellMap[V] {
+	result := make(CellMap[V], len(cm))
+	for c, v := range cm {
+		if !cs.Contains(c) {
+			result[c] = v
+		}
+	}
+	return result
+}
+
+// Rollup returns a new map of the parents at the given resolution of the
+// cells in the map. The values of the cells sharing a parent are combined with
+// reduce, in ascending cell order. Cells already at the resolution are kept as
+// part of their own group. It is an error for the map to contain cells coarser
+// than the resolution.
+func (cm CellMap[V]) Rollup(res int, reduce func([]V) V) (CellMap[V], error) {
+	if res < 0 || res > MAX_H3_RES {
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	cells := cm.Cells()
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+	groups := make(map[Cell][]V)
+	for _, c := range cells {
+		parent, err := c.Parent(res)
+		if err != nil {
+			return nil, fmt.Errorf("error getting parent for cell %s: %w", c, err)
+		}
+		groups[parent] = append(groups[parent], cm[c])
+	}
+
+	result := make(CellMap[V], len(groups))
+	for parent, values := range groups {
+		result[parent] = reduce(values)
+	}
+	return result, nil
+}
+
+// Disaggregate returns a new map of the descendants at the given resolution of
+// the cells in the map. Each value is divided among a cell's descendants with
+// split, given the fraction of the cell's area the descendant accounts for.
+// The fractions of a cell's descendants sum to 1. It is an error for the map
+// to contain cells finer than the resolution, or cells with descendants in
+// common, or for the cells to have more than MAX_DISAGGREGATE_CELLS
+// descendants in all.
+func (cm CellMap[V]) Disaggregate(res int, split func(v V, weight float64) V) (CellMap[V], error) {
+	if res < 0 || res > MAX_H3_RES {
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	// Count the descendants before making any of them.
+	count := int64(0)
+	for c := range cm {
+		if !c.Valid() || c.Resolution() > res {
+			return nil, fmt.Errorf("cell %s can't be disaggregated to resolution %d: %w", c, res, ErrInvalidArgument)
+		}
+		count += childCount(c.isPentagon(), res-c.Resolution())
+		if count > MAX_DISAGGREGATE_CELLS {
+			return nil, fmt.Errorf("cells have more than %d descendants at resolution %d: %w", MAX_DISAGGREGATE_CELLS, res, ErrInvalidArgument)
+		}
+	}
+
+	result := make(CellMap[V], count)
+	for c, v := range cm {
+		children := make([]Cell, childCount(c.isPentagon(), res-c.Resolution()))
+		areas := make([]float64, len(children))
+		total := 0.0
+		for pos := range children {
+			child, err := ChildPosToCell(int64(pos), c, res)
+			if err != nil {
+				return nil, fmt.Errorf("error getting child %d of cell %s: %w", pos, c, err)
+			}
+			area, err := child.areaRads2()
+			if err != nil {
+				return nil, fmt.Errorf("error getting area of cell %s: %w", child, err)
+			}
+			children[pos] = child
+			areas[pos] = area
+			total += area
+		}
+
+		for i, child := range children {
+			if _, ok := result[child]; ok {
+				return nil, fmt.Errorf("cell %s overlaps another cell: %w", c, ErrInvalidArgument)
+			}
+			result[child] = split(v, areas[i]/total)
+		}
+	}
+	return result, nil
+}
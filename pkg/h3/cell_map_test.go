@@ -0,0 +1,131 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func TestCellMap_setOperations(t *testing.T) {
+	a := Cell(0x85283473fffffff)
+	b := Cell(0x85283477fffffff)
+	c := Cell(0x8528347bfffffff)
+
+	cm := CellMap[int]{a: 1, b: 2}
+	assert.True(t, cm.Contains(a))
+	assert.False(t, cm.Contains(c))
+	assert.ElementsMatch(t, []Cell{a, b}, cm.Cells())
+	assert.Equal(t, NewCellSetFromCells([]Cell{a, b}), cm.CellSet())
+
+	res, err := cm.Resolution()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, res)
+	_, err = CellMap[int]{}.Resolution()
+	assert.Error(t, err)
+	_, err = CellMap[int]{a: 1, 0x8009fffffffffff: 2}.Resolution()
+	assert.Error(t, err)
+
+	union := cm.Union(CellMap[int]{b: 3, c: 4}, func(x, y int) int { return x + y })
+	assert.Equal(t, CellMap[int]{a: 1, b: 5, c: 4}, union)
+
+	assert.True(t, cm.Intersects(NewCellSetFromCells([]Cell{b, c})))
+	assert.False(t, cm.Intersects(NewCellSetFromCells([]Cell{c})))
+	assert.Equal(t, CellMap[int]{b: 2}, cm.Restrict(NewCellSetFromCells([]Cell{b, c})))
+	assert.Equal(t, CellMap[int]{a: 1}, cm.Subtract(NewCellSetFromCells([]Cell{b, c})))
+}
+
+func TestCellMap_Rollup(t *testing.T) {
+	parent := Cell(0x85283473fffffff)
+	cm := make(CellMap[float64])
+	for pos := int64(0); pos < 49; pos++ {
+		child, err := ChildPosToCell(pos, parent, 7)
+		assert.NoError(t, err)
+		cm[child] = float64(pos)
+	}
+
+	rolled, err := cm.Rollup(6, sum)
+	assert.NoError(t, err)
+	assert.Len(t, rolled, 7)
+	for c, v := range rolled {
+		pos, err := c.ChildPos(5)
+		assert.NoError(t, err)
+		// Children 7p to 7p+6 sum to 49p+21.
+		assert.Equal(t, float64(49*pos+21), v)
+	}
+
+	rolled, err = rolled.Rollup(5, sum)
+	assert.NoError(t, err)
+	assert.Equal(t, CellMap[float64]{parent: 48 * 49 / 2}, rolled)
+
+	// Values are reduced in ascending cell order.
+	first := func(values []float64) float64 { return values[0] }
+	rolled, err = cm.Rollup(5, first)
+	assert.NoError(t, err)
+	assert.Equal(t, CellMap[float64]{parent: 0}, rolled)
+
+	_, err = rolled.Rollup(6, sum)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = cm.Rollup(-1, sum)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCellMap_Disaggregate(t *testing.T) {
+	scale := func(v float64, weight float64) float64 { return v * weight }
+
+	tests := []struct {
+		name     string
+		c        Cell
+		res      int
+		children int
+	}{
+		{"hexagon", Cell(0x85283473fffffff), 7, 49},
+		{"pentagon", Cell(0x820807fffffffff), 4, 41},
+		{"same resolution", Cell(0x85283473fffffff), 5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := CellMap[float64]{tt.c: 1000}
+			children, err := cm.Disaggregate(tt.res, scale)
+			assert.NoError(t, err)
+			assert.Len(t, children, tt.children)
+
+			parentArea, err := tt.c.AreaKm2()
+			assert.NoError(t, err)
+			for c, v := range children {
+				assert.True(t, c.IsDescendantOf(tt.c))
+				// Children get values roughly in proportion to their area.
+				area, err := c.AreaKm2()
+				assert.NoError(t, err)
+				assert.InEpsilon(t, 1000*area/parentArea, v, 0.05)
+			}
+
+			rolled, err := children.Rollup(tt.c.Resolution(), sum)
+			assert.NoError(t, err)
+			assert.InDelta(t, 1000, rolled[tt.c], 1e-9)
+		})
+	}
+
+	hexagon := Cell(0x85283473fffffff)
+	child, err := hexagon.CenterChild(6)
+	assert.NoError(t, err)
+	_, err = CellMap[float64]{hexagon: 1, child: 1}.Disaggregate(7, scale)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = CellMap[float64]{child: 1}.Disaggregate(5, scale)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = CellMap[float64]{hexagon: 1}.Disaggregate(MAX_H3_RES+1, scale)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// Too many descendants fail before any are made.
+	_, err = CellMap[float64]{Cell(0x8009fffffffffff): 1}.Disaggregate(MAX_H3_RES, scale)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = CellMap[float64]{hexagon: 1, Cell(0x85283477fffffff): 1}.Disaggregate(12, scale)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,74 @@
+package h3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathString returns the cell in path notation: its resolution, base cell and
+// digits from resolution 1 down, e.g. "res3/bc20/0.6.4". Cells sharing a parent
+// share a path prefix. Resolution 0 cells have no digits, e.g. "res0/bc20".
+func (c Cell) PathString() string {
+	res := c.Resolution()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "res%d/bc%d", res, c.BaseCell())
+	for r := 1; r <= res; r++ {
+		if r == 1 {
+			b.WriteByte('/')
+		} else {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.Itoa(int(c.getIndexDigit(r))))
+	}
+	return b.String()
+}
+
+// ParseCellPath parses a cell in the path notation returned by
+// Cell.PathString. The cell must be valid.
+func ParseCellPath(s string) (Cell, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("cell path %q: expected res/bc/digits: %w", s, ErrInvalidArgument)
+	}
+
+	res, err := parsePathNumber(parts[0], "res")
+	if err != nil || res > MAX_H3_RES {
+		return 0, fmt.Errorf("cell path %q: bad resolution %q: %w", s, parts[0], ErrInvalidArgument)
+	}
+	bc, err := parsePathNumber(parts[1], "bc")
+	if err != nil || bc >= NUM_BASE_CELLS {
+		return 0, fmt.Errorf("cell path %q: bad base cell %q: %w", s, parts[1], ErrInvalidArgument)
+	}
+
+	var digits []string
+	if len(parts) == 3 {
+		digits = strings.Split(parts[2], ".")
+	}
+	if len(digits) != res {
+		return 0, fmt.Errorf("cell path %q: expected %d digits, got %d: %w", s, res, len(digits), ErrInvalidArgument)
+	}
+
+	c := newCell(res, baseCell(bc), CENTER_DIGIT)
+	for i, d := range digits {
+		if len(d) != 1 || d[0] < '0' || d[0] > '6' {
+			return 0, fmt.Errorf("cell path %q: bad digit %q: %w", s, d, ErrInvalidArgument)
+		}
+		c = c.setIndexDigit(i+1, Direction(d[0]-'0'))
+	}
+
+	if !c.Valid() {
+		return 0, fmt.Errorf("cell path %q: invalid cell %s: %w", s, c, ErrInvalidArgument)
+	}
+	return c, nil
+}
+
+// parsePathNumber parses a non-negative decimal number following prefix.
+func parsePathNumber(s, prefix string) (int, error) {
+	n, ok := strings.CutPrefix(s, prefix)
+	if !ok || n == "" || strings.HasPrefix(n, "+") || strings.HasPrefix(n, "-") {
+		return 0, ErrInvalidArgument
+	}
+	return strconv.Atoi(n)
+}
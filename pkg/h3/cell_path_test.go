@@ -0,0 +1,68 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCell_PathString(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Cell
+		want string
+	}{
+		{"hexagon", Cell(0x85283473fffffff), "res5/bc20/0.6.4.3.4"},
+		{"resolution 0", Cell(0x8029fffffffffff), "res0/bc20"},
+		{"pentagon", Cell(0x820807fffffffff), "res2/bc4/0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.c.PathString()
+			assert.Equal(t, tt.want, got)
+
+			c, err := ParseCellPath(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.c, c)
+		})
+	}
+
+	// Siblings differ only in their last digit.
+	child, err := ChildPosToCell(3, Cell(0x85283473fffffff), 6)
+	assert.NoError(t, err)
+	assert.Equal(t, "res6/bc20/0.6.4.3.4.3", child.PathString())
+}
+
+func TestParseCellPath(t *testing.T) {
+	c, err := ParseCellPath("res9/bc20/3.0.6.1.2.4.5.0.1")
+	assert.NoError(t, err)
+	assert.True(t, c.Valid())
+	assert.Equal(t, 9, c.Resolution())
+	assert.Equal(t, baseCell(20), c.BaseCell())
+	assert.Equal(t, "res9/bc20/3.0.6.1.2.4.5.0.1", c.PathString())
+
+	for _, s := range []string{
+		"",
+		"res9",
+		"res0/bc20/",
+		"res1/bc20",
+		"res2/bc20/1",
+		"res1/bc20/1.2",
+		"res1/bc20/7",
+		"res1/bc20/x",
+		"res1/bc20/11",
+		"res16/bc20/0",
+		"res-1/bc20",
+		"res0/bc122",
+		"res0/bc+1",
+		"re0/bc1",
+		"res0/bc1/0/0",
+		// Pentagons have no K axes child.
+		"res1/bc4/1",
+	} {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseCellPath(s)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
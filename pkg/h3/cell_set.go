@@ -2,6 +2,10 @@ package h3
 
 import (
 	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
 )
 
 // CellSet represents a set of H3 cells.
@@ -78,6 +82,14 @@ func (cs CellSet) Union(other CellSet) CellSet {
 // is the set of cells within k grid steps of the cells in the set. k=0 returns
 // the set itself.
 func (cs CellSet) GridDisk(k int) (CellSet, error) {
+	return cs.GridDiskParallel(k, 1)
+}
+
+// GridDiskParallel is like GridDisk, but spreads the work of each expansion
+// step across the given number of goroutines. Parallelism only pays off for
+// large sets; small steps are always expanded on the calling goroutine. If
+// workers <= 0, GOMAXPROCS goroutines are used.
+func (cs CellSet) GridDiskParallel(k int, workers int) (CellSet, error) {
 	// k<0 returns an error
 	if k < 0 {
 		return nil, fmt.Errorf("k must be >= 0")
@@ -93,43 +105,127 @@ func (cs CellSet) GridDisk(k int) (CellSet, error) {
 		return nil, fmt.Errorf("empty cell set")
 	}
 
-	// Start with the original set
-	result := make(CellSet, len(cs))
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Interior cells can't reach anything that their boundary doesn't reach
+	// first, so only the boundary of the set is expanded.
+	boundary, err := cs.boundaryCells(workers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Size the result for k rings around the set, but no more than the cells
+	// at the resolution. Neighboring boundary cells share most of their rings,
+	// so each ring is about as long as the boundary, growing by 6 cells per
+	// step.
+	var res int
+	for c := range cs {
+		res = c.Resolution()
+		break
+	}
+	size := float64(len(cs)) + float64(len(boundary))*float64(k) + 3*float64(k)*float64(k+1)
+	result := make(CellSet, int(math.Min(size, float64(getNumCellsAtResolution(res)))))
 	for c := range cs {
 		result.Add(c)
 	}
 
-	// For each k, expand only the cells added in the previous step
-	currentShell := cs
-	for i := 0; i < k; i++ {
-		// Find boundary cells of the current shell
-		nextShell := make(CellSet, len(currentShell))
+	// Each step expands only the ring of cells added in the previous step. A
+	// neighbor of a cell in the ring is in the previous ring, the ring itself, or
+	// the next ring, so new cells are found without looking up the whole result.
+	// Once a ring adds no new cells, the disk covers the whole world.
+	previousRing := cs
+	ring := boundary
+	for i := 0; i < k && len(ring) > 0; i++ {
+		candidates, err := collectNeighbors(ring.Cells(), workers, func(out []Cell, c Cell, neighbors []Cell) []Cell {
+			for _, n := range neighbors {
+				if !previousRing.Contains(n) && !ring.Contains(n) {
+					out = append(out, n)
+				}
+			}
+			return out
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		nextRing := make(CellSet, len(ring)+6)
+		for _, n := range candidates {
+			nextRing.Add(n)
+			result.Add(n)
+		}
+
+		previousRing = ring
+		ring = nextRing
+	}
+
+	return result, nil
+}
+
+// GridDiskDistances returns the cells within k grid steps of the cells in the
+// set, mapped to their grid distance from the nearest cell in the set. Cells in
+// the set itself have distance 0.
+func (cs CellSet) GridDiskDistances(k int) (map[Cell]int, error) {
+	_, distances, err := cs.GridDiskNearest(k)
+	return distances, err
+}
+
+// GridDiskNearest returns the cells within k grid steps of the cells in the
+// set, mapped to the nearest cell in the set (a discrete Voronoi labelling of
+// the grid disk) and to the grid distance from that cell. When a cell is
+// equally close to several cells in the set, the lowest cell index wins.
+//
+// Both maps are computed in a single breadth-first pass from all cells in the
+// set at once.
+func (cs CellSet) GridDiskNearest(k int) (map[Cell]Cell, map[Cell]int, error) {
+	if k < 0 {
+		return nil, nil, fmt.Errorf("k must be >= 0")
+	}
+
+	if len(cs) == 0 {
+		return nil, nil, fmt.Errorf("empty cell set")
+	}
 
-		for c := range currentShell {
-			// Get immediate neighbors of the current shell
-			neighbors, err := c.GridDisk(1)
+	nearest := make(map[Cell]Cell, len(cs))
+	distances := make(map[Cell]int, len(cs))
+	frontier := make([]Cell, 0, len(cs))
+	for c := range cs {
+		nearest[c] = c
+		distances[c] = 0
+		frontier = append(frontier, c)
+	}
+
+	var neighbors []Cell
+	for d := 1; d <= k && len(frontier) > 0; d++ {
+		next := make([]Cell, 0, len(frontier)*2)
+		for _, c := range frontier {
+			seed := nearest[c]
+
+			var err error
+			neighbors, err = c.appendNeighbors(neighbors[:0])
 			if err != nil {
-				return nil, fmt.Errorf("error getting neighbors for cell %s: %w", c, err)
+				return nil, nil, fmt.Errorf("error getting neighbors for cell %s: %w", c, err)
 			}
 
-			// Add new neighbors to the next shell
 			for _, n := range neighbors {
-				if !result.Contains(n) {
-					nextShell.Add(n)
-					result.Add(n)
+				nd, ok := distances[n]
+				if !ok {
+					nearest[n] = seed
+					distances[n] = d
+					next = append(next, n)
+				} else if nd == d && seed < nearest[n] {
+					// Reached at the same distance from another seed, keep the
+					// lowest one so the labelling is deterministic.
+					nearest[n] = seed
 				}
 			}
 		}
 
-		if len(nextShell) == 0 {
-			break // No new cells added, exit the loop
-		}
-
-		// Move to the next shell
-		currentShell = nextShell
+		frontier = next
 	}
 
-	return result, nil
+	return nearest, distances, nil
 }
 
 // GridDistance returns the minimum grid distance between cells in the two sets.
@@ -162,102 +258,238 @@ func (cs CellSet) GridDistance(other CellSet) (int, error) {
 		return 0, nil
 	}
 
-	// Group cells by their lower-resolution parent to reduce search space.
-	parentReduction := 1
-	selfGroups := make(map[Cell][]Cell)
-	otherGroups := make(map[Cell][]Cell)
+	// The closest pair of cells is always made up of boundary cells: an interior
+	// cell's neighbor towards the other set is also in its set, and is closer.
+	thisBoundary, err := cs.BoundaryCells()
+	if err != nil {
+		return 0, err
+	}
 
-	// Group cells by their parent
-	for c := range cs {
-		parent, err := c.Parent(thisResolution - parentReduction)
-		if err != nil {
-			return 0, fmt.Errorf("error getting parent for cell %s: %w", c, err)
-		}
-		selfGroups[parent] = append(selfGroups[parent], c)
+	otherBoundary, err := other.BoundaryCells()
+	if err != nil {
+		return 0, err
 	}
 
-	for c := range other {
-		parent, err := c.Parent(thisResolution - parentReduction)
-		if err != nil {
-			return 0, fmt.Errorf("error getting parent for cell %s: %w", c, err)
-		}
-		otherGroups[parent] = append(otherGroups[parent], c)
+	// Search outward from the smaller boundary.
+	sources, sourceSet, targets, targetSet := thisBoundary, cs, otherBoundary, other
+	if len(otherBoundary) < len(thisBoundary) {
+		sources, sourceSet, targets, targetSet = otherBoundary, other, thisBoundary, cs
 	}
 
-	// First find minimum distance between parent cells
-	minParentDistance := -1
-	relevantPairs := make([][2]Cell, 0, len(selfGroups)*len(otherGroups))
-	for parent1 := range selfGroups {
-		for parent2 := range otherGroups {
-			d, err := parent1.GridDistance(parent2)
-			if err != nil {
-				return 0, fmt.Errorf("error computing grid distance between parent cells %s and %s: %w", parent1, parent2, err)
-			}
+	// If every boundary cell can be placed in one local coordinate space, the
+	// distance can be found without traversing the grid. Otherwise (e.g. when the
+	// sets are far apart or across a pentagon), fall back to searching the grid,
+	// up to a distance of MAX_GRID_DISTANCE_SEARCH.
+	if d, err := gridDistanceLocal(sources, targets); err == nil {
+		return d, nil
+	}
+	far, err := beyondGridDistanceSearch(sources, targets, thisResolution)
+	if err != nil {
+		return 0, err
+	}
+	if far {
+		return 0, fmt.Errorf("cells are more than %d steps apart: %w", MAX_GRID_DISTANCE_SEARCH, ErrInvalidArgument)
+	}
+
+	return gridDistanceSearch(sources, sourceSet, targets, targetSet, MAX_GRID_DISTANCE_SEARCH)
+}
+
+// gridDistanceLocal returns the minimum grid distance between the cells of the
+// two sets. All cells are placed in the local IJK coordinate space of a single
+// anchor cell, and the targets are swept in order of their first cube
+// coordinate, which bounds the distance from below.
+func gridDistanceLocal(sources CellSet, targets CellSet) (int, error) {
+	var anchor Cell
+	for c := range sources {
+		anchor = c
+		break
+	}
+
+	sourceCubes, err := anchor.toLocalCubes(sources)
+	if err != nil {
+		return 0, err
+	}
+
+	targetCubes, err := anchor.toLocalCubes(targets)
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Slice(targetCubes, func(i, j int) bool {
+		return targetCubes[i].i < targetCubes[j].i
+	})
 
-			if minParentDistance == -1 || d < minParentDistance {
-				minParentDistance = d
-				relevantPairs = relevantPairs[:0]
+	minDistance := -1
+	for _, source := range sourceCubes {
+		start := sort.Search(len(targetCubes), func(i int) bool {
+			return targetCubes[i].i >= source.i
+		})
+
+		// Scan upwards, then downwards, until the first coordinate alone is
+		// further away than the best distance found so far.
+		for i := start; i < len(targetCubes); i++ {
+			if minDistance != -1 && targetCubes[i].i-source.i >= minDistance {
+				break
 			}
+			if d := cubeDistance(source, targetCubes[i]); minDistance == -1 || d < minDistance {
+				minDistance = d
+			}
+		}
 
-			// This pair is among the closest, so we'll check its children later
-			if d <= minParentDistance+parentReduction {
-				relevantPairs = append(relevantPairs, [2]Cell{parent1, parent2})
+		for i := start - 1; i >= 0; i-- {
+			if minDistance != -1 && source.i-targetCubes[i].i >= minDistance {
+				break
+			}
+			if d := cubeDistance(source, targetCubes[i]); minDistance == -1 || d < minDistance {
+				minDistance = d
 			}
 		}
 	}
 
-	// Now calculate the actual minimum distance between the children of the relevant pairs
-	minDistance := -1
+	return minDistance, nil
+}
+
+// toLocalCubes returns the local coordinates of every cell in the set, relative
+// to this cell, as cube coordinates.
+func (c Cell) toLocalCubes(cs CellSet) ([]coordIJK, error) {
+	cubes := make([]coordIJK, 0, len(cs))
+	for other := range cs {
+		ijk, err := c.toLocalIJK(other)
+		if err != nil {
+			return nil, fmt.Errorf("error computing local coordinates of cell %s relative to %s: %w", other, c, err)
+		}
+		cubes = append(cubes, ijk.toCube())
+	}
 
-	for _, pair := range relevantPairs {
-		parent1, parent2 := pair[0], pair[1]
-		childrenPairs1 := selfGroups[parent1]
-		childrenPairs2 := otherGroups[parent2]
+	return cubes, nil
+}
 
-		for _, c1 := range childrenPairs1 {
-			for _, c2 := range childrenPairs2 {
-				d, err := c1.GridDistance(c2)
-				if err != nil {
-					return 0, fmt.Errorf("error computing grid distance between cells %s and %s: %w", c1, c2, err)
-				}
+// cubeDistance returns the grid distance between two cube coordinates.
+func cubeDistance(a coordIJK, b coordIJK) int {
+	return max(abs(a.i-b.i), abs(a.j-b.j), abs(a.k-b.k))
+}
 
-				if minDistance == -1 || d < minDistance {
-					minDistance = d
+// gridDistanceSearch returns the grid distance between two disjoint sets of
+// cells with a breadth-first search from both of them, expanding the smaller
+// frontier each step until they meet. Only the boundary cells of each set
+// (sources and targets) start a frontier, since the other cells are surrounded
+// by their set. It gives up once the distance would exceed maxDistance.
+func gridDistanceSearch(sources, sourceSet, targets, targetSet CellSet, maxDistance int) (int, error) {
+	// Each side records the cells it has reached and its current frontier.
+	type side struct {
+		visited  CellSet
+		frontier []Cell
+	}
+	newSide := func(boundary, cells CellSet) *side {
+		visited := make(CellSet, len(cells))
+		for c := range cells {
+			visited.Add(c)
+		}
+		return &side{visited: visited, frontier: boundary.Cells()}
+	}
+	a := newSide(sources, sourceSet)
+	b := newSide(targets, targetSet)
+
+	var neighbors []Cell
+	for d := 1; d <= maxDistance; d++ {
+		if len(b.frontier) < len(a.frontier) {
+			a, b = b, a
+		}
+		if len(a.frontier) == 0 {
+			break
+		}
+
+		next := make([]Cell, 0, len(a.frontier)+6)
+		for _, cell := range a.frontier {
+			var err error
+			neighbors, err = cell.appendNeighbors(neighbors[:0])
+			if err != nil {
+				return 0, fmt.Errorf("error getting neighbors for cell %s: %w", cell, err)
+			}
+			for _, n := range neighbors {
+				// The frontiers are a step apart at most, so the first
+				// meeting is at the total distance searched.
+				if b.visited.Contains(n) {
+					return d, nil
+				}
+				if !a.visited.Contains(n) {
+					a.visited.Add(n)
+					next = append(next, n)
 				}
 			}
 		}
+		a.frontier = next
 	}
 
-	// If no distance was found, return an error
-	if minDistance == -1 {
-		return 0, fmt.Errorf("no distance found between cell sets")
+	return 0, fmt.Errorf("cells are more than %d steps apart: %w", maxDistance, ErrInvalidArgument)
+}
+
+// beyondGridDistanceSearch returns whether the cells of the two sets, at the
+// given resolution, are certainly more than MAX_GRID_DISTANCE_SEARCH grid steps
+// apart, judging by the great circle distance between their centers.
+func beyondGridDistanceSearch(sources CellSet, targets CellSet, res int) (bool, error) {
+	sourceCenter, sourceRadius, err := centersCap(sources)
+	if err != nil {
+		return false, err
+	}
+	targetCenter, targetRadius, err := centersCap(targets)
+	if err != nil {
+		return false, err
 	}
 
-	return minDistance, nil
+	d := sourceCenter.greatCircleDistanceRads(targetCenter) - sourceRadius - targetRadius
+	return d > MAX_GRID_DISTANCE_SEARCH*maxStepRads(res), nil
+}
+
+// centersCap returns the center of one of the cells, and the greatest great
+// circle distance in radians from it to the center of any of the cells.
+func centersCap(cs CellSet) (LatLng, float64, error) {
+	var center LatLng
+	radius := 0.0
+	first := true
+	for c := range cs {
+		ll, err := c.LatLng()
+		if err != nil {
+			return LatLng{}, 0, err
+		}
+		if first {
+			center, first = ll, false
+			continue
+		}
+		radius = math.Max(radius, center.greatCircleDistanceRads(ll))
+	}
+	return center, radius, nil
 }
 
 // BoundaryCells returns the cells on the outer boundary of the set. A boundary
 // cell is one that has at least one neighboring cell that's not in the set.
 func (cs CellSet) BoundaryCells() (CellSet, error) {
+	return cs.boundaryCells(1)
+}
+
+// boundaryCells is BoundaryCells spread across the given number of goroutines.
+func (cs CellSet) boundaryCells(workers int) (CellSet, error) {
 	// If the set has < 7 cells, return the set itself because there aren't enough
 	// cells to enclose one cell.
 	if len(cs) < 7 {
 		return cs, nil
 	}
 
-	boundaryCells := make(CellSet, len(cs))
-	for c := range cs {
-		neighbors, err := c.GridDisk(1)
-		if err != nil {
-			return nil, fmt.Errorf("error getting neighbors for cell %s: %w", c, err)
-		}
-
+	boundary, err := collectNeighbors(cs.Cells(), workers, func(out []Cell, c Cell, neighbors []Cell) []Cell {
 		for _, n := range neighbors {
 			if !cs.Contains(n) {
-				boundaryCells.Add(c)
-				break
+				return append(out, c)
 			}
 		}
+		return out
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boundaryCells := make(CellSet, len(boundary))
+	for _, c := range boundary {
+		boundaryCells.Add(c)
 	}
 
 	return boundaryCells, nil
@@ -365,3 +597,127 @@ func (cs CellSet) Parent(resolution int) (CellSet, error) {
 
 	return result, nil
 }
+
+// CellRange is an inclusive range of resolution 15 cell keys, as returned by
+// Cell.DescendantRange.
+type CellRange struct {
+	Min uint64
+	Max uint64
+}
+
+// Ranges returns the sorted, disjoint ranges of resolution 15 keys of the
+// descendants of the cells in the set. Ranges are merged when they overlap or
+// when no valid cell lies between them, so the children of a cell, including
+// a pentagon, merge into the cell's range. The set may have cells of mixed
+// resolutions; a compacted set gives the fewest ranges to begin with.
+func (cs CellSet) Ranges() []CellRange {
+	ranges := make([]CellRange, 0, len(cs))
+	for c := range cs {
+		first, last := c.DescendantRange()
+		ranges = append(ranges, CellRange{Min: first, Max: last})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Min < ranges[j].Min })
+
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.Min <= nextKey(merged[n-1].Max) {
+			merged[n-1].Max = max(merged[n-1].Max, r.Max)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// Outline returns the outline of the cells in the set as a multipolygon: a
+// list of polygons, each made of a counter-clockwise outer loop followed by the
+// clockwise loops of its holes. Loops are not closed, i.e. the first vertex is
+// not repeated at the end. All cells must have the same resolution, and an
+// empty set has an empty outline.
+func (cs CellSet) Outline() ([][][]LatLng, error) {
+	if len(cs) == 0 {
+		return nil, nil
+	}
+
+	if _, err := cs.Resolution(); err != nil {
+		return nil, fmt.Errorf("cell set is not consistent resolution: %w", err)
+	}
+
+	// Add the cells in a fixed order so that the output is deterministic.
+	cells := cs.Cells()
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+	graph := newVertexGraph(len(cells))
+	for _, c := range cells {
+		boundary, err := c.Boundary()
+		if err != nil {
+			return nil, fmt.Errorf("error getting boundary for cell %s: %w", c, err)
+		}
+		graph.addBoundary(boundary)
+	}
+
+	return graph.polygons(), nil
+}
+
+// minCellsPerWorker is the smallest number of cells worth handing to a separate
+// goroutine in collectNeighbors.
+const minCellsPerWorker = 1024
+
+// collectNeighbors calls collect with the neighbors of every cell in cells and
+// returns everything the calls appended. The cells are split across up to the
+// given number of goroutines, so collect must not modify shared state.
+func collectNeighbors(cells []Cell, workers int, collect func(out []Cell, c Cell, neighbors []Cell) []Cell) ([]Cell, error) {
+	workers = min(workers, len(cells)/minCellsPerWorker)
+	if workers <= 1 {
+		return collectNeighborsSerial(cells, collect)
+	}
+
+	outs := make([][]Cell, workers)
+	errs := make([]error, workers)
+	chunkSize := (len(cells) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * chunkSize
+		end := min(start+chunkSize, len(cells))
+
+		wg.Add(1)
+		go func(w int, chunk []Cell) {
+			defer wg.Done()
+			outs[w], errs[w] = collectNeighborsSerial(chunk, collect)
+		}(w, cells[start:end])
+	}
+	wg.Wait()
+
+	total := 0
+	for w := 0; w < workers; w++ {
+		if errs[w] != nil {
+			return nil, errs[w]
+		}
+		total += len(outs[w])
+	}
+
+	out := make([]Cell, 0, total)
+	for _, o := range outs {
+		out = append(out, o...)
+	}
+
+	return out, nil
+}
+
+// collectNeighborsSerial is collectNeighbors on the calling goroutine.
+func collectNeighborsSerial(cells []Cell, collect func(out []Cell, c Cell, neighbors []Cell) []Cell) ([]Cell, error) {
+	var out []Cell
+	neighbors := make([]Cell, 0, 6)
+	for _, c := range cells {
+		var err error
+		neighbors, err = c.appendNeighbors(neighbors[:0])
+		if err != nil {
+			return nil, fmt.Errorf("error getting neighbors for cell %s: %w", c, err)
+		}
+
+		out = collect(out, c, neighbors)
+	}
+
+	return out, nil
+}
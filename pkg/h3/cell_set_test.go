@@ -220,6 +220,142 @@ func TestCellSet_GridDisk(t *testing.T) {
 	}
 }
 
+func TestCellSet_GridDiskDistances(t *testing.T) {
+	t.Run("invalid k", func(t *testing.T) {
+		_, err := CellSet{0x87283082affffff: {}}.GridDiskDistances(-1)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := CellSet{}.GridDiskDistances(1)
+		assert.Error(t, err)
+	})
+
+	t.Run("k=0", func(t *testing.T) {
+		got, err := CellSet{0x87283082affffff: {}}.GridDiskDistances(0)
+		assert.NoError(t, err)
+		assert.Equal(t, map[Cell]int{0x87283082affffff: 0}, got)
+	})
+
+	t.Run("single cell k=1", func(t *testing.T) {
+		got, err := CellSet{0x87283082affffff: {}}.GridDiskDistances(1)
+		assert.NoError(t, err)
+		assert.Equal(t, map[Cell]int{
+			0x87283082affffff: 0,
+			0x87283082bffffff: 1,
+			0x87283080cffffff: 1,
+			0x872830801ffffff: 1,
+			0x872830805ffffff: 1,
+			0x87283082effffff: 1,
+			0x872830828ffffff: 1,
+		}, got)
+	})
+
+	t.Run("matches grid disk and grid distance", func(t *testing.T) {
+		cs := CellSet{0x87283082affffff: {}, 0x872830823ffffff: {}, 0x872830958ffffff: {}}
+		k := 4
+
+		got, err := cs.GridDiskDistances(k)
+		assert.NoError(t, err)
+
+		disk, err := cs.GridDisk(k)
+		assert.NoError(t, err)
+		assert.Len(t, got, len(disk))
+
+		for c, d := range got {
+			assert.True(t, disk.Contains(c), "cell %s not in grid disk", c)
+
+			want := -1
+			for seed := range cs {
+				sd, err := seed.GridDistance(c)
+				assert.NoError(t, err)
+				if want == -1 || sd < want {
+					want = sd
+				}
+			}
+			assert.Equal(t, want, d, "distance for cell %s", c)
+		}
+	})
+}
+
+func TestCellSet_GridDiskNearest(t *testing.T) {
+	cs := CellSet{0x87283082affffff: {}, 0x872830958ffffff: {}}
+
+	nearest, distances, err := cs.GridDiskNearest(5)
+	assert.NoError(t, err)
+	assert.Len(t, nearest, len(distances))
+
+	for seed := range cs {
+		assert.Equal(t, seed, nearest[seed])
+		assert.Equal(t, 0, distances[seed])
+	}
+
+	for c, seed := range nearest {
+		assert.True(t, cs.Contains(seed))
+
+		d, err := seed.GridDistance(c)
+		assert.NoError(t, err)
+		assert.Equal(t, distances[c], d, "distance from nearest seed for cell %s", c)
+
+		// Ties go to the lowest seed.
+		for other := range cs {
+			od, err := other.GridDistance(c)
+			assert.NoError(t, err)
+			assert.True(t, od > d || (od == d && other >= seed), "cell %s is closer to %s than %s", c, other, seed)
+		}
+	}
+}
+
+func TestCellSet_GridDiskParallel(t *testing.T) {
+	large, err := CellSet{0x88283082a9fffff: {}}.GridDisk(40)
+	assert.NoError(t, err)
+
+	for _, k := range []int{0, 1, 5} {
+		want, err := CellSet{0x88283082a9fffff: {}}.GridDisk(40 + k)
+		assert.NoError(t, err)
+
+		for _, workers := range []int{0, 1, 4} {
+			got, err := large.GridDiskParallel(k, workers)
+			assert.NoError(t, err)
+			assert.Equalf(t, want, got, "GridDiskParallel(%v, %v)", k, workers)
+		}
+	}
+
+	t.Run("parallel rings", func(t *testing.T) {
+		// Rings of cells 4 steps apart, so that every cell is on the boundary
+		// and the expansion is split across workers.
+		center := Cell(0x88283082a9fffff)
+		cells, distances, err := center.GridDiskDistances(60)
+		assert.NoError(t, err)
+		rings := make(CellSet)
+		for i, c := range cells {
+			if distances[i]%4 == 0 {
+				rings.Add(c)
+			}
+		}
+		boundary, err := rings.BoundaryCells()
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(boundary), 2*minCellsPerWorker)
+
+		want, err := CellSet{center: {}}.GridDisk(62)
+		assert.NoError(t, err)
+		for _, workers := range []int{1, 4} {
+			got, err := rings.GridDiskParallel(2, workers)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got, "workers %d", workers)
+		}
+	})
+
+	t.Run("whole world", func(t *testing.T) {
+		// Large k stops once the disk covers every cell.
+		for _, k := range []int{10000, 1e6, 2e9} {
+			got, err := CellSet{0x8001fffffffffff: {}}.GridDisk(k)
+			assert.NoError(t, err)
+			assert.Len(t, got, 122, "k %d", k)
+		}
+	})
+}
+
 func TestCellSet_Intersects(t *testing.T) {
 	type args struct {
 		other CellSet
@@ -364,6 +500,13 @@ func TestCellSet_GridDistance(t *testing.T) {
 			2,
 			assert.NoError,
 		},
+		{
+			"across a pentagon",
+			CellSet{0x820827fffffffff: {}},
+			args{CellSet{0x8208e7fffffffff: {}}},
+			2,
+			assert.NoError,
+		},
 		{
 			"sf to vallejo",
 			sfCells,
@@ -381,6 +524,67 @@ func TestCellSet_GridDistance(t *testing.T) {
 			assert.Equalf(t, tt.want, got, "GridDistance(%v)", tt.args.other)
 		})
 	}
+
+	t.Run("too far apart", func(t *testing.T) {
+		sf, err := NewCellFromLatLng(NewLatLng(37.7749, -122.4194), 6)
+		assert.NoError(t, err)
+		sydney, err := NewCellFromLatLng(NewLatLng(-33.8688, 151.2093), 6)
+		assert.NoError(t, err)
+
+		_, err = CellSet{sf: {}}.GridDistance(CellSet{sydney: {}})
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		a, b := CellSet{sf: {}}, CellSet{sydney: {}}
+		_, err = gridDistanceSearch(a, a, b, b, 10)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
+
+func TestCellSet_GridDistance_bruteForce(t *testing.T) {
+	origin := Cell(0x872830828ffffff)
+	disk, _, err := origin.GridDiskDistances(40)
+	assert.NoError(t, err)
+
+	// Compare sets of increasing size and separation against a pairwise scan of
+	// every cell.
+	for _, tt := range []struct{ radius, offset int }{{1, 3}, {2, 10}, {3, 30}, {8, 25}} {
+		t.Run(fmt.Sprintf("radius=%d offset=%d", tt.radius, tt.offset), func(t *testing.T) {
+			a, err := CellSet{origin: {}}.GridDisk(tt.radius)
+			assert.NoError(t, err)
+
+			other := disk[3*tt.offset*(tt.offset+1)]
+			b, err := CellSet{other: {}}.GridDisk(tt.radius)
+			assert.NoError(t, err)
+
+			want := -1
+			for c1 := range a {
+				for c2 := range b {
+					d, err := c1.GridDistance(c2)
+					assert.NoError(t, err)
+					if want == -1 || d < want {
+						want = d
+					}
+				}
+			}
+
+			got, err := a.GridDistance(b)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			got, err = b.GridDistance(a)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			// The search fallback must agree with the coordinate-based path.
+			aBoundary, err := a.BoundaryCells()
+			assert.NoError(t, err)
+			bBoundary, err := b.BoundaryCells()
+			assert.NoError(t, err)
+			got, err = gridDistanceSearch(aBoundary, a, bBoundary, b, MAX_GRID_DISTANCE_SEARCH)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
 }
 
 func BenchmarkCellSet_GridDisk(b *testing.B) {
@@ -422,6 +626,25 @@ func BenchmarkCellSet_GridDisk(b *testing.B) {
 	}
 }
 
+func BenchmarkCellSet_GridDisk_large(b *testing.B) {
+	// About 30,000 resolution 8 cells around San Francisco
+	cells, err := CellSet{0x88283082a9fffff: {}}.GridDisk(100)
+	if err != nil {
+		b.Fatalf("GridDisk() error = %v", err)
+	}
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := cells.GridDiskParallel(10, workers)
+				if err != nil {
+					b.Fatalf("GridDiskParallel() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
 func TestCellSet_BoundaryCells(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -660,3 +883,162 @@ func TestCellSet_Parent(t *testing.T) {
 		})
 	}
 }
+
+func TestCellSet_Outline(t *testing.T) {
+	origin := Cell(0x872830828ffffff)
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := CellSet{}.Outline()
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("mixed resolutions", func(t *testing.T) {
+		_, err := CellSet{origin: {}, 0x86283082fffffff: {}}.Outline()
+		assert.Error(t, err)
+	})
+
+	t.Run("single cell", func(t *testing.T) {
+		boundary, err := origin.Boundary()
+		assert.NoError(t, err)
+
+		got, err := CellSet{origin: {}}.Outline()
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Len(t, got[0], 1)
+		assert.ElementsMatch(t, boundary, got[0][0])
+	})
+
+	t.Run("disk", func(t *testing.T) {
+		for k := 0; k <= 3; k++ {
+			disk, err := CellSet{origin: {}}.GridDisk(k)
+			assert.NoError(t, err)
+
+			got, err := disk.Outline()
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Len(t, got[0], 1)
+			assert.Len(t, got[0][0], 6*(2*k+1))
+			assert.Greater(t, loopArea(got[0][0]), 0.0)
+		}
+	})
+
+	t.Run("hole", func(t *testing.T) {
+		disk, err := CellSet{origin: {}}.GridDisk(2)
+		assert.NoError(t, err)
+		delete(disk, origin)
+
+		got, err := disk.Outline()
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Len(t, got[0], 2)
+		assert.Len(t, got[0][0], 30)
+		assert.Len(t, got[0][1], 6)
+		assert.Less(t, loopArea(got[0][1]), 0.0)
+	})
+
+	t.Run("around a pole", func(t *testing.T) {
+		pole, err := NewCellFromLatLng(NewLatLng(90, 0), 3)
+		assert.NoError(t, err)
+		disk, err := CellSet{pole: {}}.GridDisk(2)
+		assert.NoError(t, err)
+		delete(disk, pole)
+
+		got, err := disk.Outline()
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Len(t, got[0], 2)
+		assert.Greater(t, loopArea(got[0][0]), 0.0)
+		assert.Less(t, loopArea(got[0][1]), 0.0)
+
+		// The hole is the polar cell.
+		area, err := pole.areaRads2()
+		assert.NoError(t, err)
+		assert.InEpsilon(t, area, -loopArea(got[0][1]), 1e-9)
+	})
+
+	t.Run("island in a hole", func(t *testing.T) {
+		disk, err := CellSet{origin: {}}.GridDisk(3)
+		assert.NoError(t, err)
+		ring, distances, err := origin.GridDiskDistances(1)
+		assert.NoError(t, err)
+		for i, c := range ring {
+			if distances[i] == 1 {
+				delete(disk, c)
+			}
+		}
+
+		got, err := disk.Outline()
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		var loops []int
+		for _, polygon := range got {
+			loops = append(loops, len(polygon))
+		}
+		assert.ElementsMatch(t, []int{1, 2}, loops)
+	})
+
+	t.Run("separate cells", func(t *testing.T) {
+		got, err := CellSet{origin: {}, 0x872830958ffffff: {}}.Outline()
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("across icosahedron edges", func(t *testing.T) {
+		// Cells around pentagons and on face edges have distortion vertices,
+		// which adjacent cells must share for their edges to cancel out.
+		for _, c := range getRes0Cells() {
+			for res := 0; res <= 3; res++ {
+				disk, err := CellSet{newCell(res, c.BaseCell(), CENTER_DIGIT): {}}.GridDisk(2)
+				assert.NoError(t, err)
+
+				got, err := disk.Outline()
+				assert.NoError(t, err)
+				assert.Len(t, got, 1, "outline of disk around %s", c)
+				if len(got) == 1 {
+					assert.Len(t, got[0], 1, "outline of disk around %s", c)
+				}
+			}
+		}
+	})
+}
+
+func TestCellSet_Ranges(t *testing.T) {
+	c := mustCellFromString("85283473fffffff")
+	first, last := c.DescendantRange()
+
+	var children []Cell
+	for d := CENTER_DIGIT; d < INVALID_DIGIT; d++ {
+		children = append(children, c.setResolution(6).setIndexDigit(6, d))
+	}
+	other := mustCellFromString("8009fffffffffff")
+	otherFirst, otherLast := other.DescendantRange()
+	var pentagonChildren []Cell
+	for d := CENTER_DIGIT; d < INVALID_DIGIT; d++ {
+		if d != K_AXES_DIGIT {
+			pentagonChildren = append(pentagonChildren, other.setResolution(1).setIndexDigit(1, d))
+		}
+	}
+
+	tests := []struct {
+		name string
+		cs   CellSet
+		want []CellRange
+	}{
+		{"empty", CellSet{}, []CellRange{}},
+		{"cell", NewCellSetFromCells([]Cell{c}), []CellRange{{first, last}}},
+		{"children merge", NewCellSetFromCells(children), []CellRange{{first, last}}},
+		{"nested cells merge", NewCellSetFromCells([]Cell{c, children[3]}), []CellRange{{first, last}}},
+		{"disjoint cells", NewCellSetFromCells([]Cell{c, other}), []CellRange{{otherFirst, otherLast}, {first, last}}},
+		{"pentagon children merge", NewCellSetFromCells(pentagonChildren), []CellRange{{otherFirst, otherLast}}},
+		{"gap", NewCellSetFromCells([]Cell{children[0], children[2]}), []CellRange{
+			{first, 0x8f2834706db6db6},
+			{0x8f2834710000000, 0x8f2834716db6db6},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cs.Ranges())
+		})
+	}
+}
@@ -280,6 +280,144 @@ func TestCell_neighborRotations(t *testing.T) {
 		_, rotations, err = origin.neighborRotations(100, rotations)
 		assert.Error(t, err, "invalid direction should fail")
 	})
+
+	t.Run("into pentagon base cells", func(t *testing.T) {
+		// Traversing into a pentagon base cell must never land in its deleted
+		// k-axes subsequence.
+		for _, origin := range []Cell{0x81013ffffffffff, 0x81017ffffffffff, 0x81073ffffffffff} {
+			for _, dir := range DIRECTIONS {
+				out, _, err := origin.neighborRotations(dir, 0)
+				assert.NoError(t, err)
+				assert.True(t, out.Valid(), "neighbor %s of %s in direction %d is invalid", out, origin, dir)
+			}
+		}
+	})
+}
+
+func TestCell_LatLng(t *testing.T) {
+	t.Run("known center", func(t *testing.T) {
+		ll, err := Cell(0x85283473fffffff).LatLng()
+		assert.NoError(t, err)
+		assert.InDelta(t, 37.34579337536848, rad2deg(ll.Latitude()), 1e-9)
+		assert.InDelta(t, -121.97637597255124, rad2deg(ll.Longitude()), 1e-9)
+	})
+
+	t.Run("invalid base cell", func(t *testing.T) {
+		_, err := Cell(0x7fffffffffffffff).LatLng()
+		assert.Error(t, err)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for lat := -90.0; lat <= 90; lat += 7.5 {
+			for lng := -180.0; lng < 180; lng += 7.5 {
+				for res := 0; res <= MAX_H3_RES; res++ {
+					c, err := NewCellFromLatLng(NewLatLng(lat, lng), res)
+					assert.NoError(t, err)
+
+					center, err := c.LatLng()
+					assert.NoError(t, err)
+
+					got, err := NewCellFromLatLng(center, res)
+					assert.NoError(t, err)
+					assert.Equal(t, c, got, "center of %s is in %s", c, got)
+				}
+			}
+		}
+	})
+
+	t.Run("round trip around pentagons", func(t *testing.T) {
+		for _, c := range getRes0Cells() {
+			if !c.BaseCell().isPentagon() {
+				continue
+			}
+
+			for res := 1; res <= 4; res++ {
+				pentagon := newCell(res, c.BaseCell(), CENTER_DIGIT)
+				disk, err := CellSet{pentagon: {}}.GridDisk(4)
+				assert.NoError(t, err)
+
+				for cell := range disk {
+					center, err := cell.LatLng()
+					assert.NoError(t, err)
+
+					got, err := NewCellFromLatLng(center, res)
+					assert.NoError(t, err)
+					assert.Equal(t, cell, got, "center of %s is in %s", cell, got)
+				}
+			}
+		}
+	})
+}
+
+func TestCell_Boundary(t *testing.T) {
+	t.Run("known boundary", func(t *testing.T) {
+		want := [][2]float64{
+			{37.271355866731895, -121.91508032705622},
+			{37.353926450852256, -121.86222328902491},
+			{37.42834118609435, -121.9235499963016},
+			{37.42012867767778, -122.0377349642703},
+			{37.33755608435298, -122.09042892904395},
+			{37.26319797461824, -122.02910130919},
+		}
+
+		got, err := Cell(0x85283473fffffff).Boundary()
+		assert.NoError(t, err)
+		assert.Len(t, got, len(want))
+		for i, v := range want {
+			assert.InDelta(t, v[0], rad2deg(got[i].Latitude()), 1e-9)
+			assert.InDelta(t, v[1], rad2deg(got[i].Longitude()), 1e-9)
+		}
+	})
+
+	t.Run("invalid base cell", func(t *testing.T) {
+		_, err := Cell(0x7fffffffffffffff).Boundary()
+		assert.Error(t, err)
+	})
+
+	t.Run("vertex counts", func(t *testing.T) {
+		// Class II pentagons have their vertices on the icosahedron edges, while
+		// Class III pentagons get a distortion vertex on every edge.
+		for res := 0; res <= 2; res++ {
+			for _, c := range getRes0Cells() {
+				cell := newCell(res, c.BaseCell(), CENTER_DIGIT)
+				boundary, err := cell.Boundary()
+				assert.NoError(t, err)
+
+				switch {
+				case !cell.isPentagon():
+					assert.GreaterOrEqual(t, len(boundary), NUM_HEX_VERTS)
+				case isResolutionClassIII(res):
+					assert.Len(t, boundary, 2*NUM_PENT_VERTS)
+				default:
+					assert.Len(t, boundary, NUM_PENT_VERTS)
+				}
+			}
+		}
+	})
+
+	t.Run("vertices surround the center", func(t *testing.T) {
+		for lat := -90.0; lat <= 90; lat += 7.5 {
+			for lng := -180.0; lng < 180; lng += 7.5 {
+				for res := 0; res <= MAX_H3_RES; res++ {
+					c, err := NewCellFromLatLng(NewLatLng(lat, lng), res)
+					assert.NoError(t, err)
+
+					center, err := c.LatLng()
+					assert.NoError(t, err)
+					boundary, err := c.Boundary()
+					assert.NoError(t, err)
+
+					// Points just inside each vertex must be in the cell.
+					for _, v := range boundary {
+						inside := center.geoAzimuthDistanceRads(center.geoAzimuthRads(v), 0.9*center.greatCircleDistanceRads(v))
+						got, err := NewCellFromLatLng(inside, res)
+						assert.NoError(t, err)
+						assert.Equal(t, c, got, "point near vertex of %s is in %s", c, got)
+					}
+				}
+			}
+		}
+	})
 }
 
 func Test_isResolutionClassIII(t *testing.T) {
@@ -627,6 +765,13 @@ func TestCell_GridDistance(t *testing.T) {
 			want:    0,
 			wantErr: assert.Error,
 		},
+		{
+			name:    "across a pentagon",
+			c:       0x820817fffffffff,
+			args:    args{other: 0x82095ffffffffff},
+			want:    3,
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "distance from invalid cell",
 			c:       0xffffffffffffffff,
@@ -644,6 +789,63 @@ func TestCell_GridDistance(t *testing.T) {
 			assert.Equalf(t, tt.want, got, "GridDistance(%v)", tt.args.other)
 		})
 	}
+
+	t.Run("around pentagons", func(t *testing.T) {
+		// Compare with breadth-first distances for every pair of cells near a
+		// pentagon, including the pairs that can't be placed in one local
+		// coordinate space.
+		for _, pentagon := range []Cell{0x8009fffffffffff, 0x820807fffffffff} {
+			disk, _, err := pentagon.GridDiskDistances(3)
+			assert.NoError(t, err)
+
+			searched := 0
+			for _, origin := range disk {
+				cells, distances, err := origin.GridDiskDistances(6)
+				assert.NoError(t, err)
+				for i, other := range cells {
+					if _, err := origin.localGridDistance(other); err != nil {
+						searched++
+					}
+					got, err := origin.GridDistance(other)
+					assert.NoError(t, err)
+					assert.Equal(t, distances[i], got, "GridDistance(%s, %s)", origin, other)
+				}
+			}
+			assert.Positive(t, searched)
+		}
+	})
+
+	t.Run("search limit", func(t *testing.T) {
+		a, b := CellSet{0x8009fffffffffff: {}}, CellSet{0x80f3fffffffffff: {}}
+		_, err := gridDistanceSearch(a, a, b, b, 2)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		d, err := gridDistanceSearch(a, a, b, b, 100)
+		assert.NoError(t, err)
+		assert.Greater(t, d, 2)
+	})
+
+	t.Run("too far apart to search", func(t *testing.T) {
+		// Cells that can't be placed in one local coordinate space, and are
+		// too far apart on the sphere to be within the search limit, fail
+		// without searching the grid.
+		sf, err := NewCellFromLatLng(NewLatLng(37.7749, -122.4194), 9)
+		assert.NoError(t, err)
+		tokyo, err := NewCellFromLatLng(NewLatLng(35.6762, 139.6503), 9)
+		assert.NoError(t, err)
+		_, err = sf.localGridDistance(tokyo)
+		assert.Error(t, err)
+
+		far, err := beyondGridDistanceSearch(CellSet{sf: {}}, CellSet{tokyo: {}}, 9)
+		assert.NoError(t, err)
+		assert.True(t, far)
+		_, err = sf.GridDistance(tokyo)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		// Cells across a pentagon are close enough to search.
+		far, err = beyondGridDistanceSearch(CellSet{0x820817fffffffff: {}}, CellSet{0x82095ffffffffff: {}}, 2)
+		assert.NoError(t, err)
+		assert.False(t, far)
+	})
 }
 
 func TestCell_Parent(t *testing.T) {
@@ -703,3 +905,230 @@ func TestCell_Parent(t *testing.T) {
 		})
 	}
 }
+
+func TestCell_DescendantRange(t *testing.T) {
+	c := mustCellFromString("85283473fffffff")
+	first, last := c.DescendantRange()
+	assert.Equal(t, uint64(0x8f2834700000000), first)
+	assert.Equal(t, uint64(0x8f2834736db6db6), last)
+
+	center, err := c.LatLng()
+	assert.NoError(t, err)
+	inside, err := NewCellFromLatLng(center, MAX_H3_RES)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, uint64(inside), first)
+	assert.LessOrEqual(t, uint64(inside), last)
+
+	neighbors, err := c.appendNeighbors(nil)
+	assert.NoError(t, err)
+	assert.Len(t, neighbors, 6)
+	for _, n := range neighbors {
+		ll, err := n.LatLng()
+		assert.NoError(t, err)
+		outside, err := NewCellFromLatLng(ll, MAX_H3_RES)
+		assert.NoError(t, err)
+		assert.False(t, uint64(outside) >= first && uint64(outside) <= last, "neighbor %s is in the range", n)
+	}
+
+	t.Run("finest resolution", func(t *testing.T) {
+		first, last := inside.DescendantRange()
+		assert.Equal(t, uint64(inside), first)
+		assert.Equal(t, uint64(inside), last)
+	})
+}
+
+func Test_nextKey(t *testing.T) {
+	tests := []struct {
+		k    uint64
+		want uint64
+	}{
+		{0x8f2834700000000, 0x8f2834700000001},
+		{0x8f2834700000005, 0x8f2834700000006},
+		{0x8f2834700000006, 0x8f2834700000008},
+		{0x8f2834736db6db6, 0x8f2834740000000},
+		{0x8f0db6db6db6db6, 0x8f0e00000000000},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, nextKey(tt.k), "key %x", tt.k)
+	}
+
+	t.Run("pentagon", func(t *testing.T) {
+		// Pentagons have no keys in their K axes subsequence, at any
+		// resolution, so the key after the center child's descendants is the
+		// first of the J axes child's.
+		pentagon := Cell(0x8009fffffffffff)
+		for res := 1; res <= MAX_H3_RES; res += 7 {
+			center, err := pentagon.CenterChild(res)
+			assert.NoError(t, err)
+			_, last := center.DescendantRange()
+			first, _ := center.setIndexDigit(res, J_AXES_DIGIT).DescendantRange()
+			assert.Equal(t, first, nextKey(last), "resolution %d", res)
+		}
+	})
+}
+
+func TestCell_CenterChild(t *testing.T) {
+	c := mustCellFromString("85283473fffffff")
+
+	child, err := c.CenterChild(7)
+	assert.NoError(t, err)
+	assert.Equal(t, mustCellFromString("872834700ffffff"), child)
+	assert.True(t, child.Valid())
+	parent, err := child.Parent(5)
+	assert.NoError(t, err)
+	assert.Equal(t, c, parent)
+
+	center, err := c.LatLng()
+	assert.NoError(t, err)
+	childCenter, err := child.LatLng()
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, center.greatCircleDistanceRads(childCenter), 1e-12)
+
+	same, err := c.CenterChild(5)
+	assert.NoError(t, err)
+	assert.Equal(t, c, same)
+
+	pentagonChild, err := Cell(0x8009fffffffffff).CenterChild(2)
+	assert.NoError(t, err)
+	assert.True(t, pentagonChild.isPentagon())
+
+	_, err = c.CenterChild(4)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = c.CenterChild(MAX_H3_RES + 1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCell_ChildPos(t *testing.T) {
+	tests := []struct {
+		name     string
+		parent   Cell
+		childRes int
+		count    int64
+	}{
+		{"hexagon", mustCellFromString("85283473fffffff"), 7, 49},
+		{"pentagon", Cell(0x8009fffffffffff), 3, 1 + 5*(343-1)/6},
+		{"pentagon child", Cell(0x820807fffffffff), 4, 41},
+		{"same resolution", mustCellFromString("85283473fffffff"), 5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := Cell(0)
+			for pos := int64(0); pos < tt.count; pos++ {
+				child, err := ChildPosToCell(pos, tt.parent, tt.childRes)
+				assert.NoError(t, err)
+				assert.True(t, child.Valid(), "%d: %s", pos, child)
+				assert.True(t, child.IsDescendantOf(tt.parent))
+				// Positions follow the numeric order of the children.
+				assert.Greater(t, child, prev)
+				prev = child
+
+				got, err := child.ChildPos(tt.parent.Resolution())
+				assert.NoError(t, err)
+				assert.Equal(t, pos, got)
+			}
+
+			_, err := ChildPosToCell(tt.count, tt.parent, tt.childRes)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+			_, err = ChildPosToCell(-1, tt.parent, tt.childRes)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+
+	c := mustCellFromString("872834700ffffff")
+	pos, err := c.ChildPos(0)
+	assert.NoError(t, err)
+	parent, err := c.Parent(0)
+	assert.NoError(t, err)
+	child, err := ChildPosToCell(pos, parent, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, c, child)
+
+	_, err = c.ChildPos(8)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Cell(0).ChildPos(0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = ChildPosToCell(0, c, 6)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = ChildPosToCell(0, c, MAX_H3_RES+1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCell_IsDescendantOf(t *testing.T) {
+	market := mustCellFromString("85283473fffffff")
+	posting, err := market.CenterChild(9)
+	assert.NoError(t, err)
+	neighbor := market.Neighbors()[0]
+
+	tests := []struct {
+		name string
+		c    Cell
+		a    Cell
+		want bool
+	}{
+		{"child", posting, market, true},
+		{"itself", market, market, true},
+		{"base cell", posting, mustCellFromString("8029fffffffffff"), true},
+		{"parent of child", market, posting, false},
+		{"neighbor", posting, neighbor, false},
+		{"invalid", Cell(0x7fffffffffffffff), market, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.c.IsDescendantOf(tt.a))
+		})
+	}
+}
+
+func TestCell_Ancestors(t *testing.T) {
+	c := mustCellFromString("85283473fffffff")
+	ancestors := c.Ancestors()
+	assert.Equal(t, []Cell{
+		mustCellFromString("8029fffffffffff"),
+		mustCellFromString("81283ffffffffff"),
+		mustCellFromString("822837fffffffff"),
+		mustCellFromString("832834fffffffff"),
+		mustCellFromString("8428347ffffffff"),
+		c,
+	}, ancestors)
+
+	assert.Equal(t, []Cell{mustCellFromString("8009fffffffffff")}, mustCellFromString("8009fffffffffff").Ancestors())
+	assert.Nil(t, Cell(0x7fffffffffffffff).Ancestors())
+}
+
+func TestLowestCommonAncestor(t *testing.T) {
+	market := mustCellFromString("85283473fffffff")
+	children := make([]Cell, 0, 7)
+	for d := CENTER_DIGIT; d < INVALID_DIGIT; d++ {
+		children = append(children, market.setResolution(6).setIndexDigit(6, d))
+	}
+	deep, err := children[3].CenterChild(10)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		cells []Cell
+		want  Cell
+	}{
+		{"one cell", []Cell{market}, market},
+		{"siblings", children, market},
+		{"mixed resolutions", []Cell{children[2], deep}, market},
+		{"ancestor and descendant", []Cell{deep, children[3]}, children[3]},
+		{"neighbors", []Cell{market, mustCellFromString("85283477fffffff")}, mustCellFromString("8428347ffffffff")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LowestCommonAncestor(tt.cells...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := LowestCommonAncestor()
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = LowestCommonAncestor(market, Cell(0x7fffffffffffffff))
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = LowestCommonAncestor(market, mustCellFromString("8009fffffffffff"))
+		assert.ErrorIs(t, err, ErrNoCommonAncestor)
+	})
+}
@@ -28,6 +28,10 @@ const (
 	M_RSIN60 = 1.1547005383792515290182975610039149112953
 	// M_ONESEVENTH is 1/7.
 	M_ONESEVENTH = 1.0 / 7.0
+	// M_ONETHIRD is 1/3.
+	M_ONETHIRD = 1.0 / 3.0
 	// M_SQRT3_2 is sqrt(3)/2.
 	M_SQRT3_2 = 0.8660254037844386467637231707529361834714
+	// EARTH_RADIUS_KM is the authalic radius of the earth in kilometers.
+	EARTH_RADIUS_KM = 6371.007180918475
 )
@@ -360,10 +360,12 @@ func (c coordIJ) toIjk() (coordIJK, error) {
 
 // toCube converts the ijk coordinates to cube coordinates and returns the result.
 func (c coordIJK) toCube() coordIJK {
+	i := -c.i + c.k
+	j := c.j - c.k
 	return coordIJK{
-		i: -c.i + c.k,
-		j: c.j - c.k,
-		k: -c.i - c.j,
+		i: i,
+		j: j,
+		k: -i - j,
 	}
 }
 
@@ -676,6 +678,44 @@ func (c coordIJK) downAp7() coordIJK {
 	return out
 }
 
+// downAp3 finds the normalized ijk coordinates of the hex centered on the
+// indicated hex at the next finer aperture 3 counter-clockwise resolution.
+func (c coordIJK) downAp3() coordIJK {
+	// res r unit vectors in res r+1
+	iVec := coordIJK{2, 0, 1}
+	jVec := coordIJK{1, 2, 0}
+	kVec := coordIJK{0, 1, 2}
+
+	iVec = iVec.scale(c.i)
+	jVec = jVec.scale(c.j)
+	kVec = kVec.scale(c.k)
+
+	out := iVec.add(jVec)
+	out = out.add(kVec)
+
+	out = out.normalize()
+	return out
+}
+
+// downAp3r finds the normalized ijk coordinates of the hex centered on the
+// indicated hex at the next finer aperture 3 clockwise resolution.
+func (c coordIJK) downAp3r() coordIJK {
+	// res r unit vectors in res r+1
+	iVec := coordIJK{2, 1, 0}
+	jVec := coordIJK{0, 2, 1}
+	kVec := coordIJK{1, 0, 2}
+
+	iVec = iVec.scale(c.i)
+	jVec = jVec.scale(c.j)
+	kVec = kVec.scale(c.k)
+
+	out := iVec.add(jVec)
+	out = out.add(kVec)
+
+	out = out.normalize()
+	return out
+}
+
 // upAp7r finds the normalized ijk coordinates of the indexing parent of a cell
 // in a clockwise aperture 7 grid.
 func (c coordIJK) upAp7r() coordIJK {
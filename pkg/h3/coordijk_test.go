@@ -172,6 +172,7 @@ func Test_coordIJK_toCube_roundtrip(t *testing.T) {
 		n := ijk.neighbor(d)
 
 		cubeIJK := n.toCube()
+		assert.Equal(t, 0, cubeIJK.i+cubeIJK.j+cubeIJK.k, "cube coordinates of %v should sum to 0", n)
 		recoveredIJK := NewCoordIJKFromCube(cubeIJK)
 
 		assert.True(t, n.matches(recoveredIJK), "expected %v, got %v", ijk, recoveredIJK)
@@ -0,0 +1,216 @@
+package h3
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// RegionCoverer covers regions with cells of mixed resolutions, using at most
+// MaxCells cells, in the manner of S2's region coverer.
+//
+// Each cell in a covering stands for its descendants at MaxRes, so every cell
+// at MaxRes that overlaps the region has exactly one ancestor (or itself) in
+// the covering. This is the form needed to query an index that stores the
+// ancestors of each point's cell at several resolutions. Note that H3 cells
+// don't contain their descendants exactly, so a covering is exact in the
+// hierarchy rather than in the plane.
+type RegionCoverer struct {
+	// MinRes is the coarsest resolution of cells in coverings.
+	MinRes int
+	// MaxRes is the finest resolution of cells in coverings.
+	MaxRes int
+	// MaxCells is the maximum number of cells in coverings. Coverings with
+	// cells at MinRes can exceed it, if the region needs more of them.
+	MaxCells int
+}
+
+// coverCandidate is a cell that may be refined into its children.
+type coverCandidate struct {
+	cell Cell
+	// terminal is whether the cell goes in the covering without refinement,
+	// because it is at MaxRes or the region contains it.
+	terminal bool
+	// children are the children that may intersect the region, once the
+	// candidate has been expanded.
+	children     []*coverCandidate
+	numTerminals int
+}
+
+// Covering returns the cells covering the region, of resolutions between
+// MinRes and MaxRes. It starts from the cells at MinRes that may intersect
+// the region, and refines them top down, coarsest first, while the covering
+// stays within MaxCells. Cells the region contains aren't refined, and cells
+// at MaxRes are tested against the region exactly.
+func (rc RegionCoverer) Covering(r Region) (CellSet, error) {
+	if rc.MinRes < 0 || rc.MaxRes > MAX_H3_RES || rc.MinRes > rc.MaxRes {
+		return nil, fmt.Errorf("resolutions %d to %d out of range: %w", rc.MinRes, rc.MaxRes, ErrInvalidArgument)
+	}
+	if rc.MaxCells < 1 {
+		return nil, fmt.Errorf("max cells %d must be positive: %w", rc.MaxCells, ErrInvalidArgument)
+	}
+
+	p, err := r.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	// The cells at MinRes overlapping the region may not include every
+	// ancestor of the overlapping cells at MaxRes, so flood out to all the
+	// cells whose descendants may intersect it.
+	overlapping, err := p.cover(rc.MinRes)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := floodCells(overlapping.Cells(), func(c Cell) (bool, error) {
+		center, radius, err := descendantCap(c)
+		if err != nil {
+			return false, err
+		}
+		intersects, _ := p.capRelation(center, radius)
+		return intersects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cv := coverer{
+		RegionCoverer: rc,
+		region:        p,
+		out:           make(CellSet),
+		queue:         &cellQueue{},
+		candidates:    make(map[Cell]*coverCandidate),
+	}
+
+	// Add the cells in a fixed order so that the output is deterministic.
+	cells := starts.Cells()
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+	for _, c := range cells {
+		candidate, err := cv.newCandidate(c)
+		if err != nil {
+			return nil, err
+		}
+		if err := cv.add(candidate); err != nil {
+			return nil, err
+		}
+	}
+
+	for cv.queue.Len() > 0 {
+		c := heap.Pop(cv.queue).(cellQueueItem).cell
+		candidate := cv.candidates[c]
+		delete(cv.candidates, c)
+		if len(candidate.children) == 1 || len(cv.out)+cv.queue.Len()+len(candidate.children) <= rc.MaxCells {
+			for _, child := range candidate.children {
+				if err := cv.add(child); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			cv.out.Add(candidate.cell)
+		}
+	}
+
+	return cv.out, nil
+}
+
+// coverer holds the state of a covering in progress.
+type coverer struct {
+	RegionCoverer
+	region preparedRegion
+	out    CellSet
+	queue  *cellQueue
+	// candidates are the queued candidates.
+	candidates map[Cell]*coverCandidate
+}
+
+// newCandidate returns a candidate for the cell, or nil if no descendant of
+// the cell at MaxRes can overlap the region.
+func (cv *coverer) newCandidate(c Cell) (*coverCandidate, error) {
+	res := c.Resolution()
+	if res == cv.MaxRes {
+		overlaps, err := cv.region.overlapsCell(c)
+		if err != nil || !overlaps {
+			return nil, err
+		}
+		return &coverCandidate{cell: c, terminal: true}, nil
+	}
+
+	center, radius, err := descendantCap(c)
+	if err != nil {
+		return nil, err
+	}
+	intersects, contains := cv.region.capRelation(center, radius)
+	if !intersects {
+		return nil, nil
+	}
+	return &coverCandidate{cell: c, terminal: contains}, nil
+}
+
+// add adds a terminal candidate to the covering, or expands the candidate's
+// children and queues it for refinement.
+func (cv *coverer) add(candidate *coverCandidate) error {
+	if candidate == nil {
+		return nil
+	}
+	if candidate.terminal {
+		cv.out.Add(candidate.cell)
+		return nil
+	}
+
+	res := candidate.cell.Resolution()
+	n := 0
+	for d := CENTER_DIGIT; d < INVALID_DIGIT; d++ {
+		// Pentagons have no child in the deleted K axes direction.
+		if d == K_AXES_DIGIT && candidate.cell.isPentagon() {
+			continue
+		}
+		n++
+		child, err := cv.newCandidate(candidate.cell.setResolution(res+1).setIndexDigit(res+1, d))
+		if err != nil {
+			return err
+		}
+		if child == nil {
+			continue
+		}
+		candidate.children = append(candidate.children, child)
+		if child.terminal {
+			candidate.numTerminals++
+		}
+	}
+
+	switch {
+	case len(candidate.children) == 0:
+		// No descendant overlaps the region.
+	case candidate.numTerminals == n:
+		// The cell stands for the same descendants as all its children.
+		cv.out.Add(candidate.cell)
+	default:
+		// Refine coarse cells first, and among those the cells with the
+		// fewest children, as they add the fewest cells to the covering.
+		priority := float64((res*8+len(candidate.children))*8 + candidate.numTerminals)
+		cv.candidates[candidate.cell] = candidate
+		heap.Push(cv.queue, cellQueueItem{cell: candidate.cell, priority: priority})
+	}
+	return nil
+}
+
+// descendantCap returns a spherical cap, as a center and a radius in radians,
+// that contains the cell and all its descendants. Descendants reach a little
+// past the cell's boundary, so the cap is half as wide again as the cell.
+func descendantCap(c Cell) (LatLng, float64, error) {
+	center, err := c.LatLng()
+	if err != nil {
+		return LatLng{}, 0, err
+	}
+	boundary, err := c.Boundary()
+	if err != nil {
+		return LatLng{}, 0, err
+	}
+
+	radius := 0.0
+	for _, v := range boundary {
+		radius = math.Max(radius, center.greatCircleDistanceRads(v))
+	}
+	return center, 1.5 * radius, nil
+}
@@ -0,0 +1,159 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// coveringError returns the number of descendants of the covering at res that
+// aren't in fine, checking that every cell in fine has exactly one ancestor in
+// the covering.
+func coveringError(t *testing.T, covering CellSet, fine CellSet, res int) float64 {
+	t.Helper()
+	for c := range fine {
+		ancestors := 0
+		for r := c.Resolution(); r >= 0; r-- {
+			p, err := c.Parent(r)
+			assert.NoError(t, err)
+			if covering.Contains(p) {
+				ancestors++
+			}
+		}
+		assert.Equal(t, 1, ancestors, "cell %s has %d ancestors in the covering", c, ancestors)
+	}
+
+	total := 0.0
+	for c := range covering {
+		total += numDescendants(c, res)
+	}
+	return total - float64(len(fine))
+}
+
+func TestRegionCoverer_Covering(t *testing.T) {
+	regions := []struct {
+		name   string
+		region Region
+	}{
+		{"rect", Rect{SouthWest: NewLatLng(37.6, -122.6), NorthEast: NewLatLng(37.9, -122.2)}},
+		{"circle", Circle{Center: NewLatLng(37.775938728915946, -122.41795063018799), RadiusKm: 10}},
+		{"polygon", Polygon{Rings: [][]LatLng{{
+			NewLatLng(37.6, -122.6),
+			NewLatLng(37.6, -122.2),
+			NewLatLng(37.9, -122.4),
+		}}}},
+		{"pentagon", Circle{Center: NewLatLng(64.7, 10.5), RadiusKm: 20}},
+	}
+
+	for _, tt := range regions {
+		t.Run(tt.name, func(t *testing.T) {
+			const maxRes = 8
+			fine, err := tt.region.cover(maxRes)
+			assert.NoError(t, err)
+
+			previous := -1.0
+			for _, maxCells := range []int{4, 8, 20, 100} {
+				rc := RegionCoverer{MinRes: 1, MaxRes: maxRes, MaxCells: maxCells}
+				covering, err := rc.Covering(tt.region)
+				assert.NoError(t, err)
+				assert.LessOrEqual(t, len(covering), maxCells)
+				for c := range covering {
+					assert.GreaterOrEqual(t, c.Resolution(), rc.MinRes)
+					assert.LessOrEqual(t, c.Resolution(), rc.MaxRes)
+				}
+
+				e := coveringError(t, covering, fine, maxRes)
+				if previous >= 0 {
+					assert.LessOrEqual(t, e, previous, "error grew with %d cells", maxCells)
+				}
+				previous = e
+			}
+
+			// With an unlimited budget the covering is exact.
+			covering, err := RegionCoverer{MinRes: 0, MaxRes: maxRes, MaxCells: len(fine)}.Covering(tt.region)
+			assert.NoError(t, err)
+			assert.Zero(t, coveringError(t, covering, fine, maxRes))
+			assert.LessOrEqual(t, len(covering), len(fine))
+		})
+	}
+
+	t.Run("min res exceeds max cells", func(t *testing.T) {
+		region := regions[0].region
+		covering, err := RegionCoverer{MinRes: 7, MaxRes: 7, MaxCells: 1}.Covering(region)
+		assert.NoError(t, err)
+		fine, err := region.cover(7)
+		assert.NoError(t, err)
+		assert.Equal(t, fine, covering)
+	})
+
+	t.Run("fine max res", func(t *testing.T) {
+		// Coverings don't enumerate the cells at MaxRes, so large regions at
+		// fine resolutions are cheap.
+		large := append(regions, struct {
+			name   string
+			region Region
+		}{"large circle", Circle{Center: NewLatLng(40, -100), RadiusKm: 1000}})
+		for _, tt := range large {
+			covering, err := RegionCoverer{MinRes: 0, MaxRes: MAX_H3_RES, MaxCells: 50}.Covering(tt.region)
+			assert.NoError(t, err, tt.name)
+			assert.NotEmpty(t, covering, tt.name)
+			assert.LessOrEqual(t, len(covering), 50, tt.name)
+
+			// Sample the cells at MaxRes overlapping the region at the
+			// centers of coarser cells, and check the covering includes an
+			// ancestor of each.
+			p, err := tt.region.prepare()
+			assert.NoError(t, err)
+			coarse, err := tt.region.cover(4)
+			assert.NoError(t, err)
+			for c := range coarse {
+				ll, err := c.LatLng()
+				assert.NoError(t, err)
+				fine, err := NewCellFromLatLng(ll, MAX_H3_RES)
+				assert.NoError(t, err)
+				overlaps, err := p.overlapsCell(fine)
+				assert.NoError(t, err)
+				if !overlaps {
+					continue
+				}
+				found := false
+				for res := MAX_H3_RES; res >= 0; res-- {
+					ancestor, err := fine.Parent(res)
+					assert.NoError(t, err)
+					found = found || covering.Contains(ancestor)
+				}
+				assert.True(t, found, "%s: cell %s has no ancestor in the covering", tt.name, fine)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		region := regions[0].region
+		for _, rc := range []RegionCoverer{
+			{MinRes: -1, MaxRes: 5, MaxCells: 8},
+			{MinRes: 5, MaxRes: MAX_H3_RES + 1, MaxCells: 8},
+			{MinRes: 6, MaxRes: 5, MaxCells: 8},
+			{MinRes: 0, MaxRes: 5, MaxCells: 0},
+		} {
+			_, err := rc.Covering(region)
+			assert.ErrorIs(t, err, ErrInvalidArgument, "%+v", rc)
+		}
+	})
+}
+
+func Test_numDescendants(t *testing.T) {
+	hex := mustCellFromString("85283473fffffff")
+	assert.Equal(t, 1.0, numDescendants(hex, 5))
+	assert.Equal(t, 49.0, numDescendants(hex, 7))
+
+	pentagon := mustCellFromString("8009fffffffffff")
+	assert.True(t, pentagon.isPentagon())
+	assert.Equal(t, 6.0, numDescendants(pentagon, 1))
+	assert.Equal(t, 41.0, numDescendants(pentagon, 2))
+}
+
+// numDescendants returns the number of descendants of the cell at the given
+// resolution.
+func numDescendants(c Cell, res int) float64 {
+	return float64(childCount(c.isPentagon(), res-c.Resolution()))
+}
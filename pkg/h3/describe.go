@@ -0,0 +1,85 @@
+package h3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CellInfo describes the fields encoded in a cell index, for debugging.
+type CellInfo struct {
+	// Cell is the described cell.
+	Cell Cell
+	// Valid is whether the cell is a valid cell index.
+	Valid bool
+	// Mode is the index mode, H3_CELL_MODE for cells.
+	Mode int
+	// Resolution is the resolution of the cell.
+	Resolution int
+	// BaseCell is the base cell number, from 0 to NUM_BASE_CELLS - 1 for
+	// valid cells.
+	BaseCell int
+	// Digits are the index digits from resolution 1 to the cell's
+	// resolution, i.e. Digits[0] is the resolution 1 digit.
+	Digits []Direction
+	// Pentagon is whether the cell is a pentagon.
+	Pentagon bool
+	// ClassIII is whether the cell's resolution is Class III.
+	ClassIII bool
+	// Face is the icosahedron face containing the cell center, or -1 if it
+	// can't be determined.
+	Face int
+	// I, J and K are the ijk+ coordinates of the cell center on Face.
+	I, J, K int
+}
+
+// Describe returns the fields encoded in the cell index. Invalid cells are
+// described as far as their bits allow.
+func (c Cell) Describe() CellInfo {
+	info := CellInfo{
+		Cell:       c,
+		Valid:      c.Valid(),
+		Mode:       c.Mode(),
+		Resolution: c.Resolution(),
+		BaseCell:   int(c.BaseCell()),
+		Digits:     make([]Direction, c.Resolution()),
+		Pentagon:   c.isPentagon(),
+		ClassIII:   c.isResolutionClassIII(),
+		Face:       -1,
+	}
+	for r := range info.Digits {
+		info.Digits[r] = c.getIndexDigit(r + 1)
+	}
+
+	if info.Valid {
+		if fijk, err := c.toFaceIjk(); err == nil {
+			info.Face = fijk.face
+			info.I, info.J, info.K = fijk.coord.i, fijk.coord.j, fijk.coord.k
+		}
+	}
+
+	return info
+}
+
+// String returns a single-line summary of the cell info.
+func (ci CellInfo) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s mode=%d res=%d base=%d digits=", ci.Cell, ci.Mode, ci.Resolution, ci.BaseCell)
+	for _, d := range ci.Digits {
+		fmt.Fprintf(&b, "%d", d)
+	}
+	if ci.Pentagon {
+		b.WriteString(" pentagon")
+	}
+	if ci.ClassIII {
+		b.WriteString(" class=III")
+	} else {
+		b.WriteString(" class=II")
+	}
+	if ci.Face >= 0 {
+		fmt.Fprintf(&b, " face=%d ijk=(%d,%d,%d)", ci.Face, ci.I, ci.J, ci.K)
+	}
+	if !ci.Valid {
+		b.WriteString(" invalid")
+	}
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCell_Describe(t *testing.T) {
+	tests := []struct {
+		name   string
+		cell   Cell
+		want   CellInfo
+		string string
+	}{
+		{
+			name: "hexagon",
+			cell: Cell(0x85283473fffffff),
+			want: CellInfo{
+				Cell:       Cell(0x85283473fffffff),
+				Valid:      true,
+				Mode:       H3_CELL_MODE,
+				Resolution: 5,
+				BaseCell:   20,
+				Digits:     []Direction{0, 6, 4, 3, 4},
+				ClassIII:   true,
+				Face:       7,
+				I:          0,
+				J:          37,
+				K:          120,
+			},
+			string: "85283473fffffff mode=1 res=5 base=20 digits=06434 class=III face=7 ijk=(0,37,120)",
+		},
+		{
+			name: "pentagon",
+			cell: Cell(0x820807fffffffff),
+			want: CellInfo{
+				Cell:       Cell(0x820807fffffffff),
+				Valid:      true,
+				Mode:       H3_CELL_MODE,
+				Resolution: 2,
+				BaseCell:   4,
+				Digits:     []Direction{0, 0},
+				Pentagon:   true,
+				Face:       0,
+				I:          14,
+			},
+			string: "820807fffffffff mode=1 res=2 base=4 digits=00 pentagon class=II face=0 ijk=(14,0,0)",
+		},
+		{
+			name: "invalid",
+			cell: Cell(0),
+			want: CellInfo{
+				Digits: []Direction{},
+				Face:   -1,
+			},
+			string: "0 mode=0 res=0 base=0 digits= class=II invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cell.Describe()
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.string, got.String())
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package h3
+
+import (
+	"fmt"
+	"math"
+)
+
+// hexagonEdgeLengthsAvgM are the average hexagon edge lengths in meters at
+// each resolution.
+var hexagonEdgeLengthsAvgM = [MAX_H3_RES + 1]float64{
+	1281256.011,
+	483056.8391,
+	182512.9565,
+	68979.22179,
+	26071.75968,
+	9854.090990,
+	3724.532667,
+	1406.475763,
+	531.4140101,
+	200.7861476,
+	75.86378287,
+	28.66389748,
+	10.83018784,
+	4.092010473,
+	1.546099657,
+	0.584168630,
+}
+
+// HexagonEdgeLengthAvgM returns the average hexagon edge length in meters at
+// the given resolution.
+func HexagonEdgeLengthAvgM(res int) (float64, error) {
+	if res < 0 || res > MAX_H3_RES {
+		return 0, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+	return hexagonEdgeLengthsAvgM[res], nil
+}
+
+// maxStepRads returns an upper bound on the great circle distance in radians
+// between the centers of neighboring cells at the resolution. No cell's
+// vertices are more than 1.5 average edge lengths from its center, and the
+// centers of neighbors are at most the sum of the cells' radii apart.
+func maxStepRads(res int) float64 {
+	return 3 * hexagonEdgeLengthsAvgM[res] / (EARTH_RADIUS_KM * 1000)
+}
+
+// MAX_DISTANCE_CELLS is the most cells that CellsWithinDistance and
+// CellsWithinDistanceMode search, as estimated from the radius. Large radii
+// at fine resolutions exceed it.
+const MAX_DISTANCE_CELLS = 1 << 20
+
+// CellsWithinDistance returns the cells at the given resolution whose centers
+// are within radiusM meters of the center point, by great circle distance.
+func CellsWithinDistance(center LatLng, radiusM float64, res int) (CellSet, error) {
+	return CellsWithinDistanceMode(center, radiusM, res, CONTAINMENT_CENTER)
+}
+
+// CellsWithinDistanceMode returns the cells at the given resolution that are
+// within radiusM meters of the center point according to the containment
+// mode: cells with their centers within the radius, cells entirely within it,
+// or cells with any part within it. It returns an error if the search would
+// cover more than about MAX_DISTANCE_CELLS cells.
+func CellsWithinDistanceMode(center LatLng, radiusM float64, res int, mode ContainmentMode) (CellSet, error) {
+	if radiusM < 0 || math.IsNaN(radiusM) || math.IsInf(radiusM, 0) {
+		return nil, fmt.Errorf("radius %f out of range: %w", radiusM, ErrInvalidArgument)
+	}
+	if mode < CONTAINMENT_CENTER || mode > CONTAINMENT_OVERLAPPING {
+		return nil, fmt.Errorf("unknown containment mode %d: %w", mode, ErrInvalidArgument)
+	}
+	edgeM, err := HexagonEdgeLengthAvgM(res)
+	if err != nil {
+		return nil, err
+	}
+	origin, err := NewCellFromLatLng(center, res)
+	if err != nil {
+		return nil, err
+	}
+
+	radius := radiusM / (EARTH_RADIUS_KM * 1000)
+	// margin bounds how much further than its center any part of a cell can
+	// be, allowing for the distortion of cell sizes.
+	margin := 2 * edgeM / (EARTH_RADIUS_KM * 1000)
+
+	// Neighboring centers are about sqrt(3) edge lengths apart. Cells vary in
+	// size, so grow the disk until its outer ring is clear of the circle. Check
+	// the size of each disk before making it; no disk is larger than the
+	// whole world.
+	numCells := float64(getNumCellsAtResolution(res))
+	checkSize := func(k float64) error {
+		if math.Min(3*k*(k+1)+1, numCells) > MAX_DISTANCE_CELLS {
+			return fmt.Errorf("radius %f covers more than %d cells at resolution %d: %w", radiusM, MAX_DISTANCE_CELLS, res, ErrInvalidArgument)
+		}
+		return nil
+	}
+	estimate := math.Min(math.Ceil(radiusM/(math.Sqrt(3)*edgeM))+1, numCells)
+	if err := checkSize(estimate); err != nil {
+		return nil, err
+	}
+	k := int(estimate)
+	for {
+		cells, distances, err := origin.GridDiskDistances(k)
+		if err != nil {
+			return nil, err
+		}
+
+		clear := true
+		for i, c := range cells {
+			if distances[i] != k {
+				continue
+			}
+			ll, err := c.LatLng()
+			if err != nil {
+				return nil, err
+			}
+			if center.greatCircleDistanceRads(ll) <= radius+margin {
+				clear = false
+				break
+			}
+		}
+		if !clear {
+			k *= 2
+			if err := checkSize(float64(k)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		out := make(CellSet)
+		for _, c := range cells {
+			within, err := cellWithinDistance(c, center, radius, mode)
+			if err != nil {
+				return nil, err
+			}
+			if within {
+				out.Add(c)
+			}
+		}
+		return out, nil
+	}
+}
+
+// cellWithinDistance returns whether the cell is within the distance in
+// radians of the point, according to the containment mode.
+func cellWithinDistance(c Cell, p LatLng, radius float64, mode ContainmentMode) (bool, error) {
+	ll, err := c.LatLng()
+	if err != nil {
+		return false, err
+	}
+	if mode == CONTAINMENT_CENTER {
+		return p.greatCircleDistanceRads(ll) <= radius, nil
+	}
+
+	boundary, err := c.Boundary()
+	if err != nil {
+		return false, err
+	}
+	switch mode {
+	case CONTAINMENT_FULL:
+		// A spherical cap is convex, so the cell is inside it if all its
+		// vertices are.
+		for _, v := range boundary {
+			if p.greatCircleDistanceRads(v) > radius {
+				return false, nil
+			}
+		}
+		return true, nil
+	default:
+		if p.greatCircleDistanceRads(ll) <= radius {
+			return true, nil
+		}
+		// The point may be inside the cell, far from its center and edges.
+		containing, err := NewCellFromLatLng(p, c.Resolution())
+		if err != nil {
+			return false, err
+		}
+		if containing == c {
+			return true, nil
+		}
+		for i, v := range boundary {
+			if p.arcDistanceRads(v, boundary[(i+1)%len(boundary)]) <= radius {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// GridDistanceAtRes returns the grid distance between two cells after bringing
+// both to the given resolution: cells finer than it are replaced by their
+// parents, and coarser cells by their center children.
+func GridDistanceAtRes(a Cell, b Cell, res int) (int, error) {
+	if res < 0 || res > MAX_H3_RES {
+		return 0, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	a, err := cellAtRes(a, res)
+	if err != nil {
+		return 0, err
+	}
+	b, err = cellAtRes(b, res)
+	if err != nil {
+		return 0, err
+	}
+
+	return a.GridDistance(b)
+}
+
+// cellAtRes returns the cell's parent or center child at the resolution.
+func cellAtRes(c Cell, res int) (Cell, error) {
+	if !c.Valid() {
+		return 0, fmt.Errorf("cell %s is invalid: %w", c, ErrInvalidArgument)
+	}
+	if res < c.Resolution() {
+		return c.Parent(res)
+	}
+	return c.CenterChild(res)
+}
+
+// GreatCircleDistanceKm returns the great circle distance in kilometers between
+// the centers of two cells, which may have different resolutions.
+func (c Cell) GreatCircleDistanceKm(other Cell) (float64, error) {
+	from, err := c.LatLng()
+	if err != nil {
+		return 0, err
+	}
+	to, err := other.LatLng()
+	if err != nil {
+		return 0, err
+	}
+
+	return from.greatCircleDistanceRads(to) * EARTH_RADIUS_KM, nil
+}
@@ -0,0 +1,171 @@
+package h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexagonEdgeLengthAvgM(t *testing.T) {
+	got, err := HexagonEdgeLengthAvgM(0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1281256.011, got, 1e-3)
+
+	got, err = HexagonEdgeLengthAvgM(MAX_H3_RES)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.584, got, 1e-3)
+
+	_, err = HexagonEdgeLengthAvgM(-1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = HexagonEdgeLengthAvgM(MAX_H3_RES + 1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCellsWithinDistance(t *testing.T) {
+	const twentyFiveMilesM = 40233.6
+
+	tests := []struct {
+		name    string
+		center  LatLng
+		radiusM float64
+		res     int
+	}{
+		{"san francisco", NewLatLng(37.775938728915946, -122.41795063018799), twentyFiveMilesM, 6},
+		{"fine cells", NewLatLng(40.689167, -74.044444), 1000, 10},
+		{"near a pentagon", NewLatLng(64.7, 10.5), 200000, 4},
+		{"antimeridian", NewLatLng(-16.5, 179.9), 50000, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CellsWithinDistance(tt.center, tt.radiusM, tt.res)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got)
+
+			// Check against every cell in a generous disk.
+			origin, err := NewCellFromLatLng(tt.center, tt.res)
+			assert.NoError(t, err)
+			edgeM, err := HexagonEdgeLengthAvgM(tt.res)
+			assert.NoError(t, err)
+			disk, err := origin.GridDisk(int(tt.radiusM/edgeM) + 2)
+			assert.NoError(t, err)
+			want := make(CellSet)
+			for _, c := range disk {
+				ll, err := c.LatLng()
+				assert.NoError(t, err)
+				if tt.center.greatCircleDistanceRads(ll)*EARTH_RADIUS_KM*1000 <= tt.radiusM {
+					want.Add(c)
+				}
+			}
+			assert.Equal(t, want, got)
+
+			full, err := CellsWithinDistanceMode(tt.center, tt.radiusM, tt.res, CONTAINMENT_FULL)
+			assert.NoError(t, err)
+			overlapping, err := CellsWithinDistanceMode(tt.center, tt.radiusM, tt.res, CONTAINMENT_OVERLAPPING)
+			assert.NoError(t, err)
+			assert.Less(t, len(full), len(got))
+			assert.Less(t, len(got), len(overlapping))
+			for c := range full {
+				assert.True(t, got.Contains(c))
+			}
+			for c := range got {
+				assert.True(t, overlapping.Contains(c))
+			}
+
+			circle, err := Circle{Center: tt.center, RadiusKm: tt.radiusM / 1000}.cover(tt.res)
+			assert.NoError(t, err)
+			assert.Equal(t, circle, overlapping)
+		})
+	}
+
+	t.Run("zero radius", func(t *testing.T) {
+		center := NewLatLng(37.775938728915946, -122.41795063018799)
+		got, err := CellsWithinDistance(center, 0, 7)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+
+		got, err = CellsWithinDistanceMode(center, 0, 7, CONTAINMENT_OVERLAPPING)
+		assert.NoError(t, err)
+		want, err := NewCellFromLatLng(center, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, NewCellSetFromCells([]Cell{want}), got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		center := NewLatLng(37.775938728915946, -122.41795063018799)
+		_, err := CellsWithinDistance(center, -1, 7)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsWithinDistance(center, 1000, MAX_H3_RES+1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsWithinDistanceMode(center, 1000, 7, ContainmentMode(3))
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("too many cells", func(t *testing.T) {
+		center := NewLatLng(37.775938728915946, -122.41795063018799)
+		_, err := CellsWithinDistance(center, 1e6, MAX_H3_RES)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsWithinDistance(center, math.MaxFloat64, 5)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		// Radii larger than the earth are fine at coarse resolutions.
+		cells, err := CellsWithinDistance(center, math.MaxFloat64, 0)
+		assert.NoError(t, err)
+		assert.Len(t, cells, 122)
+	})
+}
+
+func TestGridDistanceAtRes(t *testing.T) {
+	posting, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 7)
+	assert.NoError(t, err)
+	candidate, err := NewCellFromLatLng(NewLatLng(37.8, -122.3), 9)
+	assert.NoError(t, err)
+
+	candidateParent, err := candidate.Parent(7)
+	assert.NoError(t, err)
+	want, err := posting.GridDistance(candidateParent)
+	assert.NoError(t, err)
+
+	got, err := GridDistanceAtRes(posting, candidate, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	got, err = GridDistanceAtRes(candidate, posting, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// At a finer resolution the coarser cell is replaced by its center child.
+	postingChild, err := posting.CenterChild(9)
+	assert.NoError(t, err)
+	want, err = postingChild.GridDistance(candidate)
+	assert.NoError(t, err)
+	got, err = GridDistanceAtRes(posting, candidate, 9)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = GridDistanceAtRes(posting, candidate, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got)
+
+	_, err = GridDistanceAtRes(posting, candidate, MAX_H3_RES+1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = GridDistanceAtRes(posting, Cell(0x7fffffffffffffff), 7)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCell_GreatCircleDistanceKm(t *testing.T) {
+	sf, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 15)
+	assert.NoError(t, err)
+	ny, err := NewCellFromLatLng(NewLatLng(40.689167, -74.044444), 9)
+	assert.NoError(t, err)
+
+	got, err := sf.GreatCircleDistanceKm(ny)
+	assert.NoError(t, err)
+	assert.InDelta(t, 4129, got, 5)
+
+	got, err = sf.GreatCircleDistanceKm(sf)
+	assert.NoError(t, err)
+	assert.Zero(t, got)
+
+	_, err = sf.GreatCircleDistanceKm(Cell(0x7fffffffffffffff))
+	assert.Error(t, err)
+}
@@ -5,4 +5,7 @@ import "fmt"
 var (
 	ErrInvalidArgument     = fmt.Errorf("invalid argument")
 	ErrPentagonEncountered = fmt.Errorf("encountered a pentagon")
+	ErrNoPath              = fmt.Errorf("no path between cells")
+	ErrInvalidEncoding     = fmt.Errorf("invalid cell set encoding")
+	ErrNoCommonAncestor    = fmt.Errorf("cells have no common ancestor")
 )
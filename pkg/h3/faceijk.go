@@ -0,0 +1,558 @@
+package h3
+
+import "math"
+
+const (
+	// IJ is the IJ quadrant faceNeighbors table direction.
+	IJ = 1
+	// KI is the KI quadrant faceNeighbors table direction.
+	KI = 2
+	// JK is the JK quadrant faceNeighbors table direction.
+	JK = 3
+
+	// INVALID_FACE is an invalid face index.
+	INVALID_FACE = -1
+)
+
+// overage indicates whether a coordinate has crossed onto an adjacent face.
+type overage int
+
+const (
+	// NO_OVERAGE means the coordinate is on the original face.
+	NO_OVERAGE = overage(0)
+	// FACE_EDGE means the coordinate is on a face edge (only occurs on substrate grids).
+	FACE_EDGE = overage(1)
+	// NEW_FACE means the coordinate is on an adjacent face.
+	NEW_FACE = overage(2)
+)
+
+// faceOrientIJK is the orientation of an adjacent face relative to a face.
+type faceOrientIJK struct {
+	// face is the adjacent face number.
+	face int
+	// translate is the res 0 translation relative to the primary face.
+	translate coordIJK
+	// ccwRot60 is the number of 60 degree ccw rotations relative to the primary
+	// face.
+	ccwRot60 int
+}
+
+var (
+	// faceNeighbors is the definition of which faces neighbor each other, and how
+	// to translate and rotate coordinates onto them. Indexed by face, then by
+	// quadrant (central face, IJ, KI, JK).
+	faceNeighbors = [NUM_ICOSA_FACES][4]faceOrientIJK{
+		{
+			// face 0
+			{0, coordIJK{0, 0, 0}, 0}, // central face
+			{4, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{1, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{5, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 1
+			{1, coordIJK{0, 0, 0}, 0}, // central face
+			{0, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{2, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{6, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 2
+			{2, coordIJK{0, 0, 0}, 0}, // central face
+			{1, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{3, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{7, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 3
+			{3, coordIJK{0, 0, 0}, 0}, // central face
+			{2, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{4, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{8, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 4
+			{4, coordIJK{0, 0, 0}, 0}, // central face
+			{3, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{0, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{9, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 5
+			{5, coordIJK{0, 0, 0}, 0},  // central face
+			{10, coordIJK{2, 2, 0}, 3}, // ij quadrant
+			{14, coordIJK{2, 0, 2}, 3}, // ki quadrant
+			{0, coordIJK{0, 2, 2}, 3},  // jk quadrant
+		},
+		{
+			// face 6
+			{6, coordIJK{0, 0, 0}, 0},  // central face
+			{11, coordIJK{2, 2, 0}, 3}, // ij quadrant
+			{10, coordIJK{2, 0, 2}, 3}, // ki quadrant
+			{1, coordIJK{0, 2, 2}, 3},  // jk quadrant
+		},
+		{
+			// face 7
+			{7, coordIJK{0, 0, 0}, 0},  // central face
+			{12, coordIJK{2, 2, 0}, 3}, // ij quadrant
+			{11, coordIJK{2, 0, 2}, 3}, // ki quadrant
+			{2, coordIJK{0, 2, 2}, 3},  // jk quadrant
+		},
+		{
+			// face 8
+			{8, coordIJK{0, 0, 0}, 0},  // central face
+			{13, coordIJK{2, 2, 0}, 3}, // ij quadrant
+			{12, coordIJK{2, 0, 2}, 3}, // ki quadrant
+			{3, coordIJK{0, 2, 2}, 3},  // jk quadrant
+		},
+		{
+			// face 9
+			{9, coordIJK{0, 0, 0}, 0},  // central face
+			{14, coordIJK{2, 2, 0}, 3}, // ij quadrant
+			{13, coordIJK{2, 0, 2}, 3}, // ki quadrant
+			{4, coordIJK{0, 2, 2}, 3},  // jk quadrant
+		},
+		{
+			// face 10
+			{10, coordIJK{0, 0, 0}, 0}, // central face
+			{5, coordIJK{2, 2, 0}, 3},  // ij quadrant
+			{6, coordIJK{2, 0, 2}, 3},  // ki quadrant
+			{15, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 11
+			{11, coordIJK{0, 0, 0}, 0}, // central face
+			{6, coordIJK{2, 2, 0}, 3},  // ij quadrant
+			{7, coordIJK{2, 0, 2}, 3},  // ki quadrant
+			{16, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 12
+			{12, coordIJK{0, 0, 0}, 0}, // central face
+			{7, coordIJK{2, 2, 0}, 3},  // ij quadrant
+			{8, coordIJK{2, 0, 2}, 3},  // ki quadrant
+			{17, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 13
+			{13, coordIJK{0, 0, 0}, 0}, // central face
+			{8, coordIJK{2, 2, 0}, 3},  // ij quadrant
+			{9, coordIJK{2, 0, 2}, 3},  // ki quadrant
+			{18, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 14
+			{14, coordIJK{0, 0, 0}, 0}, // central face
+			{9, coordIJK{2, 2, 0}, 3},  // ij quadrant
+			{5, coordIJK{2, 0, 2}, 3},  // ki quadrant
+			{19, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 15
+			{15, coordIJK{0, 0, 0}, 0}, // central face
+			{16, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{19, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{10, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 16
+			{16, coordIJK{0, 0, 0}, 0}, // central face
+			{17, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{15, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{11, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 17
+			{17, coordIJK{0, 0, 0}, 0}, // central face
+			{18, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{16, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{12, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 18
+			{18, coordIJK{0, 0, 0}, 0}, // central face
+			{19, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{17, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{13, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+		{
+			// face 19
+			{19, coordIJK{0, 0, 0}, 0}, // central face
+			{15, coordIJK{2, 0, 2}, 1}, // ij quadrant
+			{18, coordIJK{2, 2, 0}, 5}, // ki quadrant
+			{14, coordIJK{0, 2, 2}, 3}, // jk quadrant
+		},
+	}
+
+	// maxDimByCIIres is the overage distance table, indexed by Class II
+	// resolution.
+	maxDimByCIIres = [MAX_H3_RES + 2]int{
+		2,        // res  0
+		-1,       // res  1
+		14,       // res  2
+		-1,       // res  3
+		98,       // res  4
+		-1,       // res  5
+		686,      // res  6
+		-1,       // res  7
+		4802,     // res  8
+		-1,       // res  9
+		33614,    // res 10
+		-1,       // res 11
+		235298,   // res 12
+		-1,       // res 13
+		1647086,  // res 14
+		-1,       // res 15
+		11529602, // res 16
+	}
+
+	// unitScaleByCIIres is the unit scale distance table, indexed by Class II
+	// resolution.
+	unitScaleByCIIres = [MAX_H3_RES + 2]int{
+		1,       // res  0
+		-1,      // res  1
+		7,       // res  2
+		-1,      // res  3
+		49,      // res  4
+		-1,      // res  5
+		343,     // res  6
+		-1,      // res  7
+		2401,    // res  8
+		-1,      // res  9
+		16807,   // res 10
+		-1,      // res 11
+		117649,  // res 12
+		-1,      // res 13
+		823543,  // res 14
+		-1,      // res 15
+		5764801, // res 16
+	}
+)
+
+// adjustOverageClassII adjusts a FaceIJK address in place so that the resulting
+// cell address is relative to the correct icosahedral face.
+//
+// res is the Class II resolution of the address, pentLeading4 is whether the
+// address is on a pentagon base cell with a leading 4 digit, and substrate is
+// whether the address is on a substrate grid. Returns the adjusted address and
+// whether an overage occurred.
+func (f faceIJK) adjustOverageClassII(res int, pentLeading4 bool, substrate bool) (faceIJK, overage) {
+	out := NO_OVERAGE
+	ijk := f.coord
+
+	// get the maximum dimension value; scale if a substrate grid
+	maxDim := maxDimByCIIres[res]
+	if substrate {
+		maxDim *= 3
+	}
+
+	// check for overage
+	if substrate && ijk.i+ijk.j+ijk.k == maxDim {
+		// on edge
+		out = FACE_EDGE
+	} else if ijk.i+ijk.j+ijk.k > maxDim {
+		// overage
+		out = NEW_FACE
+
+		var fijkOrient faceOrientIJK
+		if ijk.k > 0 {
+			if ijk.j > 0 {
+				// jk "quadrant"
+				fijkOrient = faceNeighbors[f.face][JK]
+			} else {
+				// ik "quadrant"
+				fijkOrient = faceNeighbors[f.face][KI]
+
+				// adjust for the pentagonal missing sequence
+				if pentLeading4 {
+					// translate origin to center of pentagon, rotate to adjust for the
+					// missing sequence, and translate the origin back to the center of
+					// the triangle
+					origin := coordIJK{maxDim, 0, 0}
+					tmp := ijk.subtract(origin)
+					tmp = tmp.rotate60cw()
+					ijk = tmp.add(origin)
+				}
+			}
+		} else {
+			// ij "quadrant"
+			fijkOrient = faceNeighbors[f.face][IJ]
+		}
+
+		f.face = fijkOrient.face
+
+		// rotate and translate for adjacent face
+		for i := 0; i < fijkOrient.ccwRot60; i++ {
+			ijk = ijk.rotate60ccw()
+		}
+
+		unitScale := unitScaleByCIIres[res]
+		if substrate {
+			unitScale *= 3
+		}
+		ijk = ijk.add(fijkOrient.translate.scale(unitScale))
+		ijk = ijk.normalize()
+
+		// overage points on pentagon boundaries can end up on edges
+		if substrate && ijk.i+ijk.j+ijk.k == maxDim {
+			out = FACE_EDGE
+		}
+	}
+
+	f.coord = ijk
+	return f, out
+}
+
+// toLatLng determines the center point in spherical coordinates of a cell given
+// by a FaceIJK address at the specified resolution.
+func (f faceIJK) toLatLng(res int) LatLng {
+	v := ijkToHex2d(f.coord)
+	return hex2dToLatLng(v, f.face, res, false)
+}
+
+// hex2dToLatLng determines the center point in spherical coordinates of a cell
+// given by 2D hex coordinates on a particular icosahedral face. substrate is
+// whether the coordinates are on a substrate grid.
+func hex2dToLatLng(v vec2d, face int, res int, substrate bool) LatLng {
+	// calculate (r, theta) in hex2d
+	r := v.Mag()
+
+	if r < EPSILON {
+		return faceCenterGeo[face]
+	}
+
+	theta := math.Atan2(v.y, v.x)
+
+	// scale for current resolution length u
+	for i := 0; i < res; i++ {
+		r *= M_RSQRT7
+	}
+
+	// scale accordingly if this is a substrate grid
+	if substrate {
+		r *= M_ONETHIRD
+		if isResolutionClassIII(res) {
+			r *= M_RSQRT7
+		}
+	}
+
+	r *= RES0_U_GNOMONIC
+
+	// perform inverse gnomonic scaling of r
+	r = math.Atan(r)
+
+	// adjust theta for Class III; if a substrate grid, then it's already been
+	// adjusted for Class III
+	if !substrate && isResolutionClassIII(res) {
+		theta = posAngleRads(theta + M_AP7_ROT_RADS)
+	}
+
+	// find theta as an azimuth
+	theta = posAngleRads(faceAxesAzRadsCII[face][0] - theta)
+
+	// now find the point at (r,theta) from the face center
+	return faceCenterGeo[face].geoAzimuthDistanceRads(theta, r)
+}
+
+const (
+	// NUM_HEX_VERTS is the number of vertices of a hexagon.
+	NUM_HEX_VERTS = 6
+	// NUM_PENT_VERTS is the number of vertices of a pentagon.
+	NUM_PENT_VERTS = 5
+)
+
+var (
+	// hexVertsCII are the vertices of an origin-centered cell in a Class II
+	// resolution on a substrate grid with aperture sequence 33r. The aperture 3
+	// gets us the vertices, and the 3r gets us back to Class II. Vertices are
+	// listed ccw from the i-axes.
+	hexVertsCII = [NUM_HEX_VERTS]coordIJK{
+		{2, 1, 0},
+		{1, 2, 0},
+		{0, 2, 1},
+		{0, 1, 2},
+		{1, 0, 2},
+		{2, 0, 1},
+	}
+
+	// hexVertsCIII are the vertices of an origin-centered cell in a Class III
+	// resolution on a substrate grid with aperture sequence 33r7r. The aperture 3
+	// gets us the vertices, and the 3r7r gets us to Class II. Vertices are listed
+	// ccw from the i-axes.
+	hexVertsCIII = [NUM_HEX_VERTS]coordIJK{
+		{5, 4, 0},
+		{1, 5, 0},
+		{0, 5, 4},
+		{0, 1, 5},
+		{4, 0, 5},
+		{5, 0, 1},
+	}
+)
+
+// adjacentFaceDir returns the faceNeighbors quadrant (IJ, KI or JK) through
+// which face to is reached from face from, 0 if they are the same face, or
+// INVALID_FACE if they aren't adjacent.
+func adjacentFaceDir(from int, to int) int {
+	for dir, orient := range faceNeighbors[from] {
+		if orient.face == to {
+			return dir
+		}
+	}
+	return INVALID_FACE
+}
+
+// faceEdge returns the end points in hex2d coordinates of the edge of the
+// icosahedron face in the given quadrant, on a substrate grid at the given
+// Class II resolution.
+func faceEdge(dir int, res int) (vec2d, vec2d) {
+	maxDim := float64(maxDimByCIIres[res])
+	v0 := vec2d{3.0 * maxDim, 0.0}
+	v1 := vec2d{-1.5 * maxDim, 3.0 * M_SQRT3_2 * maxDim}
+	v2 := vec2d{-1.5 * maxDim, -3.0 * M_SQRT3_2 * maxDim}
+
+	switch dir {
+	case IJ:
+		return v0, v1
+	case JK:
+		return v1, v2
+	default:
+		return v2, v0
+	}
+}
+
+// toVerts returns the FaceIJK addresses of the vertices of the cell given by
+// this FaceIJK address at the given resolution, on the substrate grid, along
+// with the Class II resolution of that substrate grid. Only the first
+// numVerts vertices are returned; pentagons have NUM_PENT_VERTS.
+func (f faceIJK) toVerts(res int, numVerts int) ([]faceIJK, int) {
+	// get the correct set of substrate vertices for this resolution
+	verts := hexVertsCII
+	if isResolutionClassIII(res) {
+		verts = hexVertsCIII
+	}
+
+	// adjust the center point to be in an aperture 33r substrate grid
+	center := f.coord.downAp3()
+	center = center.downAp3r()
+
+	// if res is Class III we need to add a cw aperture 7 to get to icosahedral
+	// Class II
+	if isResolutionClassIII(res) {
+		center = center.downAp7r()
+		res++
+	}
+
+	// The center point is now in the same substrate grid as the origin cell
+	// vertices. Add the center point substrate coordinates to each vertex to
+	// translate the vertices to that cell.
+	out := make([]faceIJK, numVerts)
+	for v := range out {
+		out[v] = faceIJK{
+			face:  f.face,
+			coord: center.add(verts[v]).normalize(),
+		}
+	}
+
+	return out, res
+}
+
+// toCellBoundary returns the vertices of the hexagonal cell given by this
+// FaceIJK address at the given resolution, in counter-clockwise order.
+//
+// Each face of the underlying icosahedron is a different projection plane, so
+// where an edge of a Class III cell crosses an icosahedron edge, an additional
+// vertex is introduced at the intersection. Class II cells have their vertices
+// on the face edges, so they need no extra vertices.
+func (f faceIJK) toCellBoundary(res int) []LatLng {
+	fijkVerts, adjRes := f.toVerts(res, NUM_HEX_VERTS)
+	out := make([]LatLng, 0, 2*NUM_HEX_VERTS)
+
+	// one more iteration checks for a distortion vertex on the last edge
+	lastFace := -1
+	lastOverage := NO_OVERAGE
+	for vert := 0; vert < NUM_HEX_VERTS+1; vert++ {
+		v := vert % NUM_HEX_VERTS
+		fijk, over := fijkVerts[v].adjustOverageClassII(adjRes, false, true)
+
+		if isResolutionClassIII(res) && vert > 0 && fijk.face != lastFace && lastOverage != FACE_EDGE {
+			// find hex2d of the two vertices on the original face
+			lastV := (v + 5) % NUM_HEX_VERTS
+			orig2d0 := ijkToHex2d(fijkVerts[lastV].coord)
+			orig2d1 := ijkToHex2d(fijkVerts[v].coord)
+
+			// find the appropriate icosahedron face edge
+			face2 := lastFace
+			if lastFace == f.face {
+				face2 = fijk.face
+			}
+			edge0, edge1 := faceEdge(adjacentFaceDir(f.face, face2), adjRes)
+
+			// If the intersection occurs at a cell vertex, then each adjacent cell
+			// edge lies completely on a single face, and no additional vertex is
+			// required.
+			inter := vec2dIntersect(orig2d0, orig2d1, edge0, edge1)
+			if !orig2d0.almostEqual(inter) && !orig2d1.almostEqual(inter) {
+				out = append(out, hex2dToLatLng(inter, f.face, adjRes, true))
+			}
+		}
+
+		if vert < NUM_HEX_VERTS {
+			out = append(out, hex2dToLatLng(ijkToHex2d(fijk.coord), fijk.face, adjRes, true))
+		}
+
+		lastFace = fijk.face
+		lastOverage = over
+	}
+
+	return out
+}
+
+// toPentagonBoundary returns the vertices of the pentagonal cell given by this
+// FaceIJK address at the given resolution, in counter-clockwise order.
+//
+// All Class III pentagon edges cross icosahedron edges, so they have an
+// additional vertex on each edge.
+func (f faceIJK) toPentagonBoundary(res int) []LatLng {
+	fijkVerts, adjRes := f.toVerts(res, NUM_PENT_VERTS)
+	out := make([]LatLng, 0, 2*NUM_PENT_VERTS)
+
+	// one more iteration checks for a distortion vertex on the last edge
+	var lastFijk faceIJK
+	for vert := 0; vert < NUM_PENT_VERTS+1; vert++ {
+		v := vert % NUM_PENT_VERTS
+
+		fijk := fijkVerts[v]
+		for over := NEW_FACE; over == NEW_FACE; {
+			fijk, over = fijk.adjustOverageClassII(adjRes, false, true)
+		}
+
+		if isResolutionClassIII(res) && vert > 0 {
+			// find hex2d of the two vertices on the last face
+			orig2d0 := ijkToHex2d(lastFijk.coord)
+
+			orient := faceNeighbors[fijk.face][adjacentFaceDir(fijk.face, lastFijk.face)]
+			tmp := faceIJK{face: orient.face, coord: fijk.coord}
+
+			// rotate and translate for adjacent face
+			for i := 0; i < orient.ccwRot60; i++ {
+				tmp.coord = tmp.coord.rotate60ccw()
+			}
+			tmp.coord = tmp.coord.add(orient.translate.scale(unitScaleByCIIres[adjRes] * 3))
+			tmp.coord = tmp.coord.normalize()
+			orig2d1 := ijkToHex2d(tmp.coord)
+
+			// find the appropriate icosahedron face edge, and add the intersection
+			edge0, edge1 := faceEdge(adjacentFaceDir(tmp.face, fijk.face), adjRes)
+			inter := vec2dIntersect(orig2d0, orig2d1, edge0, edge1)
+			out = append(out, hex2dToLatLng(inter, tmp.face, adjRes, true))
+		}
+
+		if vert < NUM_PENT_VERTS {
+			out = append(out, hex2dToLatLng(ijkToHex2d(fijk.coord), fijk.face, adjRes, true))
+		}
+
+		lastFijk = fijk
+	}
+
+	return out
+}
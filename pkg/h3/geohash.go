@@ -0,0 +1,163 @@
+package h3
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// MAX_GEOHASH_PRECISION is the maximum number of characters in a geohash
+	// supported by the geohash functions.
+	MAX_GEOHASH_PRECISION = 12
+
+	// MAX_CELL_KEYS is the most geohashes or quadkeys that GeohashesForCell and
+	// QuadkeysForCell consider for a cell. Coarse cells at high precisions, and
+	// polar cells, which span every longitude, exceed it.
+	MAX_CELL_KEYS = 1 << 20
+
+	// geohashAlphabet is the base 32 alphabet of geohashes.
+	geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+// geohashBits returns the number of longitude and latitude bits in a geohash
+// of the given precision. Bits alternate between longitude and latitude,
+// starting with longitude.
+func geohashBits(precision int) (int, int) {
+	bits := 5 * precision
+	return (bits + 1) / 2, bits / 2
+}
+
+// geohashBbox returns the bounding box of the geohash.
+func geohashBbox(gh string) (bbox, error) {
+	if len(gh) < 1 || len(gh) > MAX_GEOHASH_PRECISION {
+		return bbox{}, fmt.Errorf("geohash %q has invalid length: %w", gh, ErrInvalidArgument)
+	}
+
+	var lngIdx, latIdx uint64
+	bit := 0
+	for _, r := range strings.ToLower(gh) {
+		v := strings.IndexRune(geohashAlphabet, r)
+		if v < 0 {
+			return bbox{}, fmt.Errorf("geohash %q has invalid character %q: %w", gh, r, ErrInvalidArgument)
+		}
+		for i := 4; i >= 0; i-- {
+			b := uint64(v>>i) & 1
+			if bit%2 == 0 {
+				lngIdx = lngIdx<<1 | b
+			} else {
+				latIdx = latIdx<<1 | b
+			}
+			bit++
+		}
+	}
+
+	lngBits, latBits := geohashBits(len(gh))
+	width := M_2PI / float64(uint64(1)<<lngBits)
+	height := math.Pi / float64(uint64(1)<<latBits)
+	return bbox{
+		north: -M_PI_2 + float64(latIdx+1)*height,
+		south: -M_PI_2 + float64(latIdx)*height,
+		east:  -math.Pi + float64(lngIdx+1)*width,
+		west:  -math.Pi + float64(lngIdx)*width,
+	}, nil
+}
+
+// geohash encodes the longitude and latitude indexes of a geohash cell.
+func geohash(lngIdx uint64, latIdx uint64, precision int) string {
+	lngBits, latBits := geohashBits(precision)
+
+	var b strings.Builder
+	v := 0
+	for bit := 0; bit < 5*precision; bit++ {
+		if bit%2 == 0 {
+			lngBits--
+			v = v<<1 | int(lngIdx>>lngBits)&1
+		} else {
+			latBits--
+			v = v<<1 | int(latIdx>>latBits)&1
+		}
+		if bit%5 == 4 {
+			b.WriteByte(geohashAlphabet[v])
+			v = 0
+		}
+	}
+	return b.String()
+}
+
+// CellsForGeohash returns the cells at the given resolution that cover the
+// geohash, according to the containment mode. Geohashes are case insensitive.
+//
+// CONTAINMENT_CENTER maps every cell to exactly one geohash of a precision,
+// which makes it the mode to use when indexing data keyed by geohashes.
+func CellsForGeohash(gh string, res int, mode ContainmentMode) (CellSet, error) {
+	if res < 0 || res > MAX_H3_RES {
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	b, err := geohashBbox(gh)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.coverCells(res, mode)
+}
+
+// GeohashesForCell returns the geohashes of the given precision that overlap
+// the cell, in sorted order. It returns an error if the cell's bounding box
+// spans more than MAX_CELL_KEYS geohashes.
+func GeohashesForCell(c Cell, precision int) ([]string, error) {
+	if precision < 1 || precision > MAX_GEOHASH_PRECISION {
+		return nil, fmt.Errorf("geohash precision %d out of range: %w", precision, ErrInvalidArgument)
+	}
+
+	cellBbox, err := newBboxFromCell(c)
+	if err != nil {
+		return nil, err
+	}
+
+	lngBits, latBits := geohashBits(precision)
+	numLng := uint64(1) << lngBits
+	numLat := uint64(1) << latBits
+	index := func(v float64, min float64, size float64, n uint64) uint64 {
+		i := math.Floor((v - min) / size * float64(n))
+		return uint64(math.Max(0, math.Min(float64(n-1), i)))
+	}
+
+	west := index(cellBbox.west, -math.Pi, M_2PI, numLng)
+	east := index(cellBbox.east, -math.Pi, M_2PI, numLng)
+	if cellBbox.isTransmeridian() || (cellBbox.west == -math.Pi && cellBbox.east == math.Pi) {
+		// Wrap around the antimeridian, visiting each column once.
+		east += numLng
+		if east-west >= numLng {
+			west, east = 0, numLng-1
+		}
+	}
+	south := index(cellBbox.south, -M_PI_2, math.Pi, numLat)
+	north := index(cellBbox.north, -M_PI_2, math.Pi, numLat)
+	if (east-west+1)*(north-south+1) > MAX_CELL_KEYS {
+		return nil, fmt.Errorf("cell %s spans more than %d geohashes of precision %d: %w", c, MAX_CELL_KEYS, precision, ErrInvalidArgument)
+	}
+
+	var out []string
+	for lngIdx := west; lngIdx <= east; lngIdx++ {
+		for latIdx := south; latIdx <= north; latIdx++ {
+			gh := geohash(lngIdx%numLng, latIdx, precision)
+			b, err := geohashBbox(gh)
+			if err != nil {
+				return nil, err
+			}
+			overlaps, err := b.overlapsCell(c)
+			if err != nil {
+				return nil, err
+			}
+			if overlaps {
+				out = append(out, gh)
+			}
+		}
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
@@ -0,0 +1,172 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_geohashBbox(t *testing.T) {
+	b, err := geohashBbox("ezs42")
+	assert.NoError(t, err)
+	assert.InDelta(t, 42.583, rad2deg(b.south), 0.001)
+	assert.InDelta(t, 42.627, rad2deg(b.north), 0.001)
+	assert.InDelta(t, -5.625, rad2deg(b.west), 0.001)
+	assert.InDelta(t, -5.581, rad2deg(b.east), 0.001)
+
+	upper, err := geohashBbox("EZS42")
+	assert.NoError(t, err)
+	assert.Equal(t, b, upper)
+
+	for _, gh := range []string{"", "ezs4a", "ezs4i", "0123456789bcd"} {
+		_, err := geohashBbox(gh)
+		assert.ErrorIs(t, err, ErrInvalidArgument, "geohash %q", gh)
+	}
+}
+
+func Test_geohash(t *testing.T) {
+	for _, gh := range []string{"0", "z", "ezs42", "9q8yyk8yuv", "s00000000000", "zzzzzzzzzzzz"} {
+		b, err := geohashBbox(gh)
+		assert.NoError(t, err)
+
+		// Re-encode the geohash from the indexes of its bounding box.
+		lngBits, latBits := geohashBits(len(gh))
+		lngIdx := uint64((b.center().Longitude() + 3.141592653589793) / M_2PI * float64(uint64(1)<<lngBits))
+		latIdx := uint64((b.center().Latitude() + M_PI_2) / 3.141592653589793 * float64(uint64(1)<<latBits))
+		assert.Equal(t, gh, geohash(lngIdx, latIdx, len(gh)))
+	}
+}
+
+func TestCellsForGeohash(t *testing.T) {
+	const res = 7
+
+	t.Run("overlapping", func(t *testing.T) {
+		cells, err := CellsForGeohash("9q8yy", res, CONTAINMENT_OVERLAPPING)
+		assert.NoError(t, err)
+
+		b, err := geohashBbox("9q8yy")
+		assert.NoError(t, err)
+		const steps = 10
+		for i := 0; i <= steps; i++ {
+			for j := 0; j <= steps; j++ {
+				p := LatLng{
+					b.south + b.heightRads()*float64(i)/steps,
+					b.west + b.widthRads()*float64(j)/steps,
+				}
+				c, err := NewCellFromLatLng(p, res)
+				assert.NoError(t, err)
+				assert.True(t, cells.Contains(c), "cell %s at %v is missing", c, p)
+			}
+		}
+	})
+
+	t.Run("center partitions cells", func(t *testing.T) {
+		// Neighboring geohashes don't share any cells.
+		seen := make(CellSet)
+		for _, gh := range []string{"9q8yu", "9q8yv", "9q8yy", "9q8yz"} {
+			cells, err := CellsForGeohash(gh, res, CONTAINMENT_CENTER)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, cells)
+			for c := range cells {
+				assert.False(t, seen.Contains(c), "cell %s is in two geohashes", c)
+				seen.Add(c)
+
+				center, err := c.LatLng()
+				assert.NoError(t, err)
+				hashes, err := GeohashesForCell(c, len(gh))
+				assert.NoError(t, err)
+				assert.Contains(t, hashes, gh, "cell %s at %v", c, center)
+			}
+		}
+	})
+
+	t.Run("full", func(t *testing.T) {
+		full, err := CellsForGeohash("9q8yy", res+1, CONTAINMENT_FULL)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, full)
+		for c := range full {
+			hashes, err := GeohashesForCell(c, 5)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"9q8yy"}, hashes)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := CellsForGeohash("9q8yy", MAX_H3_RES+1, CONTAINMENT_CENTER)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForGeohash("9q8ya", res, CONTAINMENT_CENTER)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForGeohash("9q8yy", res, ContainmentMode(7))
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
+
+func TestGeohashesForCell(t *testing.T) {
+	t.Run("small cell", func(t *testing.T) {
+		c, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 12)
+		assert.NoError(t, err)
+		hashes, err := GeohashesForCell(c, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"9q8yy"}, hashes)
+	})
+
+	t.Run("large cell", func(t *testing.T) {
+		c := mustCellFromString("85283473fffffff")
+		hashes, err := GeohashesForCell(c, 4)
+		assert.NoError(t, err)
+		assert.Greater(t, len(hashes), 1)
+		assert.IsIncreasing(t, hashes)
+
+		// Every geohash overlaps the cell, and the geohashes cover it.
+		boundary, err := c.Boundary()
+		assert.NoError(t, err)
+		center, err := c.LatLng()
+		assert.NoError(t, err)
+		for _, p := range append(boundary, center) {
+			covered := false
+			for _, gh := range hashes {
+				b, err := geohashBbox(gh)
+				assert.NoError(t, err)
+				covered = covered || b.containsPoint(p)
+			}
+			assert.True(t, covered, "point %v isn't covered", p)
+		}
+	})
+
+	t.Run("polar cell", func(t *testing.T) {
+		// The cell spans every longitude, so it overlaps a whole row of
+		// geohashes.
+		c, err := NewCellFromLatLng(NewLatLng(90, 0), 2)
+		assert.NoError(t, err)
+		hashes, err := GeohashesForCell(c, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "c", "f", "g", "u", "v", "y", "z"}, hashes)
+
+		_, err = GeohashesForCell(c, MAX_GEOHASH_PRECISION)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		c, err := NewCellFromLatLng(NewLatLng(0, 180), 2)
+		assert.NoError(t, err)
+		hashes, err := GeohashesForCell(c, 1)
+		assert.NoError(t, err)
+		// The cell straddles geohashes on both sides of the antimeridian and
+		// the equator.
+		assert.Equal(t, []string{"2", "8", "r", "x"}, hashes)
+	})
+
+	t.Run("pole", func(t *testing.T) {
+		hashes, err := GeohashesForCell(mustCellFromString("8001fffffffffff"), 1)
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 8)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		c := mustCellFromString("85283473fffffff")
+		_, err := GeohashesForCell(c, 0)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = GeohashesForCell(c, MAX_GEOHASH_PRECISION+1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
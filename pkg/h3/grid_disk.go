@@ -33,20 +33,40 @@ func maxGridDiskSize(k int) (int, error) {
 }
 
 // gridDiskDistancesSafe is the safe but slow version of GridDiskDistances (also
-// called by it when needed).
-//
-// Includes the origin cell in the output list (treating it as a hash set) and
-// recurses to its neighbors, if needed.
+// called by it when needed). It works around pentagons and their distortion
+// areas by searching the grid breadth-first, so the cells come out in order of
+// increasing distance, as they do from the unsafe version.
 func (c Cell) gridDiskDistancesSafe(k int) ([]Cell, []int, error) {
 	maxIdx, err := maxGridDiskSize(k)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	out := make([]Cell, maxIdx)
-	distances := make([]int, maxIdx)
-	err = c.gridDiskDistancesInternal(k, &out, &distances, maxIdx, 0)
-	return out, distances, err
+	// Large disks wrap around the world, so they can't hold more cells than
+	// there are at the resolution.
+	size := min(maxIdx, getNumCellsAtResolution(c.Resolution()))
+	cells := make([]Cell, 1, size)
+	distances := make([]int, 1, size)
+	cells[0] = c
+	seen := make(CellSet, size)
+	seen.Add(c)
+
+	var neighbors []Cell
+	for i := 0; i < len(cells) && distances[i] < k; i++ {
+		neighbors, err = cells[i].appendNeighbors(neighbors[:0])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, n := range neighbors {
+			if !seen.Contains(n) {
+				seen.Add(n)
+				cells = append(cells, n)
+				distances = append(distances, distances[i]+1)
+			}
+		}
+	}
+
+	return cells, distances, nil
 }
 
 // gridDiskDistancesUnsafe produces indexes within k distance of the origin
@@ -149,7 +169,8 @@ func (c Cell) GridDisk(k int) ([]Cell, error) {
 }
 
 // GridDiskDistances produces cells and their distances from the given origin
-// cell, up to distance k.
+// cell, up to distance k. The cells are returned in order of increasing
+// distance, starting with the origin.
 //
 // k-ring 0 is defined as the origin cell, k-ring 1 is defined as k-ring 0 and
 // all neighboring cells, and so on.
@@ -161,59 +182,31 @@ func (c Cell) GridDiskDistances(k int) ([]Cell, []int, error) {
 	}
 
 	// If the unsafe version failed, fall back to the safe version
-	maxIdx, err := maxGridDiskSize(k)
-	if err != nil {
-		return nil, nil, err
-	}
-	out := make([]Cell, maxIdx)
-	distances = make([]int, maxIdx)
-	err = c.gridDiskDistancesInternal(k, &out, &distances, maxIdx, 0)
-	return out, distances, err
+	return c.gridDiskDistancesSafe(k)
 }
 
-func (c Cell) gridDiskDistancesInternal(k int, out *[]Cell, distances *[]int, maxIdx int, curK int) error {
-	// Put origin in the output array, which is used as a hash set
-	off := uint64(c) % uint64(maxIdx)
-	for (*out)[off] != 0 && (*out)[off] != c {
-		off = (off + 1) % uint64(maxIdx)
-	}
-
-	// We either got a free slot in the hash set or hit a duplicate
-	// We might need to process the duplicate anyways because we got
-	// here on a longer path before.
-	if (*out)[off] == c && (*distances)[off] <= curK {
-		return nil
-	}
-
-	(*out)[off] = c
-	(*distances)[off] = curK
-
-	// Base case: reached an index k away from the origin.
-	if curK >= k {
-		return nil
-	}
-
-	// Recurse to all neighbors in no particular order
+// appendNeighbors appends the cells adjacent to this cell to out and returns the
+// extended slice. Neighbors are appended in DIRECTIONS order; pentagons only
+// have five neighbors because their k-axes direction is deleted.
+func (c Cell) appendNeighbors(out []Cell) ([]Cell, error) {
 	for i := 0; i < 6; i++ {
-		rotations := 0
-		nextNeighbor, rotations, neighborResult := c.neighborRotations(DIRECTIONS[i], rotations)
-		if !errors.Is(neighborResult, ErrPentagonEncountered) {
-			// ErrPentagonEncountered is an expected case when trying to traverse off of
-			// pentagons.
-			if neighborResult != nil {
-				return neighborResult
-			}
-
-			neighborResult = nextNeighbor.gridDiskDistancesInternal(
-				k, out, distances, maxIdx, curK+1)
-
-			if neighborResult != nil {
-				return neighborResult
-			}
+		if DIRECTIONS[i] == K_AXES_DIGIT && c.isPentagon() {
+			// Resolution 0 pentagons are deflected to another neighbor rather
+			// than failing, which would repeat that neighbor.
+			continue
+		}
+		neighbor, _, err := c.neighborRotations(DIRECTIONS[i], 0)
+		if errors.Is(err, ErrPentagonEncountered) {
+			// Expected when trying to traverse off of pentagons.
+			continue
+		}
+		if err != nil {
+			return out, err
 		}
+		out = append(out, neighbor)
 	}
 
-	return nil
+	return out, nil
 }
 
 func getNumCellsAtResolution(res int) int {
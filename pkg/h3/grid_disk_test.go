@@ -3,6 +3,7 @@ package h3
 import (
 	"fmt"
 	"math"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -138,18 +139,77 @@ func TestCell_gridDisk(t *testing.T) {
 		assert.Len(t, distances, 7)
 
 		// First cell is the origin, so it should have a distance of 0. The rest should be 1.
-		expectedDistances := []int{1, 1, 1, 0, 1, 1, 1}
+		expectedDistances := []int{0, 1, 1, 1, 1, 1, 1}
 		assert.Equal(t, expectedDistances, distances)
 
-		expectedCells := []Cell{
+		assert.Equal(t, sf, cells[0])
+		expectedNeighbors := []Cell{
 			mustCellFromString("8051fffffffffff"),
 			mustCellFromString("8049fffffffffff"),
 			mustCellFromString("8027fffffffffff"),
-			sf,
 			mustCellFromString("801dfffffffffff"),
 			mustCellFromString("8037fffffffffff"),
 			mustCellFromString("8013fffffffffff"),
 		}
-		assert.Equal(t, expectedCells, cells)
+		assert.ElementsMatch(t, expectedNeighbors, cells[1:])
+	})
+}
+
+func TestCell_gridDiskDistancesSafe(t *testing.T) {
+	assertSortedDisk := func(t *testing.T, cells []Cell, distances []int) {
+		t.Helper()
+		assert.Len(t, distances, len(cells))
+		assert.True(t, sort.IntsAreSorted(distances), "distances are not sorted")
+		seen := make(CellSet, len(cells))
+		for _, c := range cells {
+			assert.True(t, c.Valid(), "cell %s is invalid", c)
+			assert.False(t, seen.Contains(c), "cell %s is repeated", c)
+			seen.Add(c)
+		}
+	}
+
+	t.Run("matches the unsafe version", func(t *testing.T) {
+		sf, err := NewCellFromLatLng(NewLatLng(37.813318, -122.40929), 7)
+		assert.NoError(t, err)
+
+		cells, distances, err := sf.gridDiskDistancesSafe(5)
+		assert.NoError(t, err)
+		assertSortedDisk(t, cells, distances)
+
+		wantCells, wantDistances, err := sf.gridDiskDistancesUnsafe(5)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, wantCells, cells)
+		want := make(map[Cell]int)
+		for i, c := range wantCells {
+			want[c] = wantDistances[i]
+		}
+		for i, c := range cells {
+			assert.Equal(t, want[c], distances[i], "distance of %s", c)
+		}
+	})
+
+	t.Run("pentagon", func(t *testing.T) {
+		pentagon := mustCellFromString("8009fffffffffff")
+		cells, distances, err := pentagon.GridDiskDistances(1)
+		assert.NoError(t, err)
+		assertSortedDisk(t, cells, distances)
+		assert.Equal(t, pentagon, cells[0])
+		assert.Equal(t, []int{0, 1, 1, 1, 1, 1}, distances)
+
+		cells, distances, err = mustCellFromString("8109bffffffffff").GridDiskDistances(4)
+		assert.NoError(t, err)
+		assertSortedDisk(t, cells, distances)
+	})
+
+	t.Run("whole world", func(t *testing.T) {
+		cells, distances, err := mustCellFromString("8009fffffffffff").GridDiskDistances(100)
+		assert.NoError(t, err)
+		assertSortedDisk(t, cells, distances)
+		assert.Len(t, cells, NUM_BASE_CELLS)
+	})
+
+	t.Run("invalid cell", func(t *testing.T) {
+		_, _, err := Cell(0x7fffffffffffffff).gridDiskDistancesSafe(1)
+		assert.Error(t, err)
 	})
 }
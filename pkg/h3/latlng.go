@@ -30,6 +30,16 @@ func (l LatLng) Longitude() float64 {
 	return l[1]
 }
 
+// LatitudeDegrees returns the latitude in degrees.
+func (l LatLng) LatitudeDegrees() float64 {
+	return rad2deg(l[0])
+}
+
+// LongitudeDegrees returns the longitude in degrees.
+func (l LatLng) LongitudeDegrees() float64 {
+	return rad2deg(l[1])
+}
+
 // NewLatLng creates a new LatLng from the given latitude and longitude in degrees.
 func NewLatLng(lat float64, lng float64) LatLng {
 	return NewLatLngRads(deg2rad(lat), deg2rad(lng))
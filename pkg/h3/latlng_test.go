@@ -233,6 +233,12 @@ func Test_deg2rad_invertible(t *testing.T) {
 	assert.InDelta(t, 360, rad2deg(deg2rad(360)), EPSILON_RAD, "360 degrees")
 }
 
+func TestLatLng_degrees(t *testing.T) {
+	ll := NewLatLng(37.775938728915946, -122.41795063018799)
+	assert.InDelta(t, 37.775938728915946, ll.LatitudeDegrees(), EPSILON_DEG)
+	assert.InDelta(t, -122.41795063018799, ll.LongitudeDegrees(), EPSILON_DEG)
+}
+
 func TestLatLng_greatCircleDistanceRads(t *testing.T) {
 	type args struct {
 		other LatLng
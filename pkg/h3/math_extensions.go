@@ -37,3 +37,11 @@ func ipow(base int, exp int) int {
 	}
 	return result
 }
+
+// abs returns the absolute value of an integer.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
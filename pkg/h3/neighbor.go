@@ -0,0 +1,72 @@
+package h3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Neighbor returns the neighbor of the cell in the given direction, or the
+// cell itself for CENTER_DIGIT. Directions are relative to the orientation of
+// the cell's base cell, so they only line up between cells on the same base
+// cell. Pentagons have no neighbor in the K_AXES_DIGIT direction, for which
+// ErrPentagonEncountered is returned.
+func (c Cell) Neighbor(dir Direction) (Cell, error) {
+	if !c.Valid() {
+		return 0, fmt.Errorf("cell %s is invalid: %w", c, ErrInvalidArgument)
+	}
+	if dir < CENTER_DIGIT || dir >= INVALID_DIGIT {
+		return 0, fmt.Errorf("direction %d out of range: %w", dir, ErrInvalidArgument)
+	}
+	if dir == K_AXES_DIGIT && c.isPentagon() {
+		// Resolution 0 pentagons would be deflected to the neighbor in the
+		// next direction instead.
+		return 0, fmt.Errorf("pentagon %s has no neighbor in the k-axes direction: %w", c, ErrPentagonEncountered)
+	}
+	n, _, err := c.neighborRotations(dir, 0)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Neighbors returns the cells adjacent to the cell, in DIRECTIONS order,
+// skipping the deleted k-axes direction of pentagons. Hexagons have six
+// neighbors and pentagons five. It returns nil for an invalid cell.
+func (c Cell) Neighbors() []Cell {
+	if !c.Valid() {
+		return nil
+	}
+
+	neighbors, err := c.appendNeighbors(make([]Cell, 0, 6))
+	if err != nil {
+		return nil
+	}
+	return neighbors
+}
+
+// DirectionTo returns the direction from the cell to a neighboring cell, such
+// that c.Neighbor(dir) is the neighbor, or CENTER_DIGIT for the cell itself. It
+// is an error if the cells aren't neighbors.
+func (c Cell) DirectionTo(neighbor Cell) (Direction, error) {
+	if !c.Valid() {
+		return INVALID_DIGIT, fmt.Errorf("cell %s is invalid: %w", c, ErrInvalidArgument)
+	}
+	if c == neighbor {
+		return CENTER_DIGIT, nil
+	}
+
+	for _, dir := range DIRECTIONS {
+		n, err := c.Neighbor(dir)
+		if errors.Is(err, ErrPentagonEncountered) {
+			continue
+		}
+		if err != nil {
+			return INVALID_DIGIT, err
+		}
+		if n == neighbor {
+			return dir, nil
+		}
+	}
+
+	return INVALID_DIGIT, fmt.Errorf("cells %s and %s are not neighbors: %w", c, neighbor, ErrInvalidArgument)
+}
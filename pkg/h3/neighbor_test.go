@@ -0,0 +1,92 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCell_Neighbors(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Cell
+		want int
+	}{
+		{"hexagon", mustCellFromString("85283473fffffff"), 6},
+		{"hexagon next to a pentagon", mustCellFromString("820817fffffffff"), 6},
+		{"pentagon", mustCellFromString("8009fffffffffff"), 5},
+		{"fine pentagon", mustCellFromString("820807fffffffff"), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			neighbors := tt.c.Neighbors()
+			assert.Len(t, neighbors, tt.want)
+
+			disk, err := tt.c.GridDisk(1)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, disk[1:], neighbors)
+
+			for _, n := range neighbors {
+				dir, err := tt.c.DirectionTo(n)
+				assert.NoError(t, err)
+				assert.NotEqual(t, CENTER_DIGIT, dir)
+				got, err := tt.c.Neighbor(dir)
+				assert.NoError(t, err)
+				assert.Equal(t, n, got)
+
+				_, err = n.DirectionTo(tt.c)
+				assert.NoError(t, err, "%s is not a neighbor of %s", tt.c, n)
+			}
+		})
+	}
+
+	t.Run("invalid cell", func(t *testing.T) {
+		assert.Nil(t, Cell(0x7fffffffffffffff).Neighbors())
+	})
+}
+
+func TestCell_Neighbor(t *testing.T) {
+	c := mustCellFromString("85283473fffffff")
+
+	self, err := c.Neighbor(CENTER_DIGIT)
+	assert.NoError(t, err)
+	assert.Equal(t, c, self)
+
+	// Neighbors in opposite directions are two steps apart.
+	i, err := c.Neighbor(I_AXES_DIGIT)
+	assert.NoError(t, err)
+	jk, err := c.Neighbor(JK_AXES_DIGIT)
+	assert.NoError(t, err)
+	d, err := i.GridDistance(jk)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d)
+
+	_, err = mustCellFromString("8009fffffffffff").Neighbor(K_AXES_DIGIT)
+	assert.ErrorIs(t, err, ErrPentagonEncountered)
+	_, err = c.Neighbor(INVALID_DIGIT)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = c.Neighbor(Direction(-1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Cell(0x7fffffffffffffff).Neighbor(I_AXES_DIGIT)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCell_DirectionTo(t *testing.T) {
+	c := mustCellFromString("85283473fffffff")
+
+	dir, err := c.DirectionTo(c)
+	assert.NoError(t, err)
+	assert.Equal(t, CENTER_DIGIT, dir)
+
+	far, err := c.Neighbor(I_AXES_DIGIT)
+	assert.NoError(t, err)
+	far, err = far.Neighbor(I_AXES_DIGIT)
+	assert.NoError(t, err)
+	_, err = c.DirectionTo(far)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = c.DirectionTo(mustCellFromString("8009fffffffffff"))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Cell(0x7fffffffffffffff).DirectionTo(c)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
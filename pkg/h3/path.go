@@ -0,0 +1,286 @@
+package h3
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// CostFunc returns the cost of stepping from a cell to one of its neighbors,
+// and whether the step is allowed at all. Costs must not be negative.
+type CostFunc func(from Cell, to Cell) (float64, bool)
+
+// HeuristicFunc estimates the cost of the cheapest path from a cell to the
+// goal. To find optimal paths, it must be consistent: it must never
+// overestimate that cost, nor drop by more than the cost of a step between
+// neighboring cells.
+type HeuristicFunc func(c Cell, goal Cell) float64
+
+// UniformCost is a CostFunc where every step is allowed and costs 1.
+func UniformCost(_ Cell, _ Cell) (float64, bool) {
+	return 1, true
+}
+
+// AvoidCells returns a CostFunc where every step costs 1, except that cells in
+// the blocked set can't be entered.
+func AvoidCells(blocked CellSet) CostFunc {
+	return func(_ Cell, to Cell) (float64, bool) {
+		return 1, !blocked.Contains(to)
+	}
+}
+
+// GridDistanceHeuristic returns a HeuristicFunc that estimates the cost to the
+// goal as the grid distance to it, multiplied by minStepCost. It never
+// overestimates as long as every step costs at least minStepCost. The grid
+// distance is only found in local coordinates, so that estimates stay cheap;
+// cells that can't be placed in one local coordinate space, e.g. across a
+// pentagon, are estimated at 0.
+func GridDistanceHeuristic(minStepCost float64) HeuristicFunc {
+	return func(c Cell, goal Cell) float64 {
+		d, err := c.localGridDistance(goal)
+		if err != nil {
+			return 0
+		}
+		return float64(d) * minStepCost
+	}
+}
+
+// zeroHeuristic estimates every cost as 0, which turns A* search into
+// Dijkstra's algorithm.
+func zeroHeuristic(_ Cell, _ Cell) float64 {
+	return 0
+}
+
+// GreatCircleHeuristic returns a HeuristicFunc that estimates the cost to the
+// goal as the great circle distance in kilometers between the cell centers,
+// multiplied by costPerKm. It never overestimates as long as every step costs
+// at least costPerKm times the distance between the centers of the cells.
+func GreatCircleHeuristic(costPerKm float64) HeuristicFunc {
+	return func(c Cell, goal Cell) float64 {
+		from, err := c.LatLng()
+		if err != nil {
+			return 0
+		}
+
+		to, err := goal.LatLng()
+		if err != nil {
+			return 0
+		}
+
+		return from.greatCircleDistanceRads(to) * EARTH_RADIUS_KM * costPerKm
+	}
+}
+
+// ShortestPath finds the cheapest path between two cells of the same
+// resolution using Dijkstra's algorithm over cell adjacency, which is optimal
+// for any non-negative costs. It returns the cells on the path, including both
+// ends, and the total cost of the path. When a lower bound on step costs is
+// known, ShortestPathWithHeuristic with GridDistanceHeuristic searches fewer
+// cells.
+//
+// Steps that cost reports as not allowed are treated as obstacles. If the goal
+// can't be reached, ErrNoPath is returned. The search only stops once every
+// reachable cell has been visited, so when the goal may be unreachable, cost
+// should also refuse steps that leave the area of interest.
+func ShortestPath(from Cell, to Cell, cost CostFunc) ([]Cell, float64, error) {
+	return ShortestPathWithHeuristic(from, to, cost, zeroHeuristic)
+}
+
+// ShortestPathWithHeuristic is like ShortestPath, but uses A* search with the
+// given heuristic to guide it. The path is only guaranteed to be the cheapest
+// if the heuristic never overestimates the cost to the goal. Cells reached more
+// cheaply after they were visited are visited again, so heuristics needn't be
+// consistent, but consistent ones search fewer cells.
+func ShortestPathWithHeuristic(from Cell, to Cell, cost CostFunc, heuristic HeuristicFunc) ([]Cell, float64, error) {
+	if !from.Valid() || !to.Valid() {
+		return nil, 0, ErrInvalidArgument
+	}
+
+	if from.Resolution() != to.Resolution() {
+		return nil, 0, ErrResolutionMismatch
+	}
+
+	costs := map[Cell]float64{from: 0}
+	previous := make(map[Cell]Cell)
+	closed := make(CellSet)
+
+	open := &cellQueue{}
+	heap.Push(open, cellQueueItem{cell: from, priority: heuristic(from, to)})
+
+	var neighbors []Cell
+	for open.Len() > 0 {
+		current := heap.Pop(open).(cellQueueItem).cell
+		if current == to {
+			return tracePath(previous, from, to), costs[to], nil
+		}
+
+		// The queue may hold stale entries for cells already reached more cheaply.
+		if closed.Contains(current) {
+			continue
+		}
+		closed.Add(current)
+
+		var err error
+		neighbors, err = current.appendNeighbors(neighbors[:0])
+		if err != nil {
+			return nil, 0, fmt.Errorf("error getting neighbors for cell %s: %w", current, err)
+		}
+
+		for _, n := range neighbors {
+			stepCost, ok := cost(current, n)
+			if !ok {
+				continue
+			}
+			if stepCost < 0 {
+				return nil, 0, fmt.Errorf("negative cost %v stepping from cell %s to %s: %w", stepCost, current, n, ErrInvalidArgument)
+			}
+
+			newCost := costs[current] + stepCost
+			if oldCost, ok := costs[n]; ok && oldCost <= newCost {
+				continue
+			}
+
+			costs[n] = newCost
+			previous[n] = current
+			delete(closed, n)
+			heap.Push(open, cellQueueItem{cell: n, priority: newCost + heuristic(n, to)})
+		}
+	}
+
+	return nil, 0, ErrNoPath
+}
+
+// Reachable returns every cell that can be reached from the origin cells
+// without the total cost exceeding budget, mapped to the cost of the cheapest
+// way to reach it. Origin cells have cost 0.
+//
+// This is the weighted counterpart of CellSet.GridDiskDistances: with
+// UniformCost and an integer budget k, it reaches the same cells. Steps that
+// cost reports as not allowed are treated as obstacles.
+func Reachable(origins CellSet, budget float64, cost CostFunc) (map[Cell]float64, error) {
+	if len(origins) == 0 {
+		return nil, fmt.Errorf("empty cell set")
+	}
+
+	if math.IsNaN(budget) || budget < 0 {
+		return nil, fmt.Errorf("budget must be >= 0: %w", ErrInvalidArgument)
+	}
+
+	for c := range origins {
+		if !c.Valid() {
+			return nil, fmt.Errorf("invalid origin cell %s: %w", c, ErrInvalidArgument)
+		}
+	}
+
+	costs := make(map[Cell]float64, len(origins))
+	closed := make(CellSet, len(origins))
+	open := &cellQueue{}
+	for c := range origins {
+		costs[c] = 0
+		heap.Push(open, cellQueueItem{cell: c, priority: 0})
+	}
+
+	var neighbors []Cell
+	for open.Len() > 0 {
+		current := heap.Pop(open).(cellQueueItem).cell
+
+		// The queue may hold stale entries for cells already reached more cheaply.
+		if closed.Contains(current) {
+			continue
+		}
+		closed.Add(current)
+
+		var err error
+		neighbors, err = current.appendNeighbors(neighbors[:0])
+		if err != nil {
+			return nil, fmt.Errorf("error getting neighbors for cell %s: %w", current, err)
+		}
+
+		for _, n := range neighbors {
+			if closed.Contains(n) {
+				continue
+			}
+
+			stepCost, ok := cost(current, n)
+			if !ok {
+				continue
+			}
+			if stepCost < 0 {
+				return nil, fmt.Errorf("negative cost %v stepping from cell %s to %s: %w", stepCost, current, n, ErrInvalidArgument)
+			}
+
+			newCost := costs[current] + stepCost
+			if newCost > budget {
+				continue
+			}
+			if oldCost, ok := costs[n]; ok && oldCost <= newCost {
+				continue
+			}
+
+			costs[n] = newCost
+			heap.Push(open, cellQueueItem{cell: n, priority: newCost})
+		}
+	}
+
+	return costs, nil
+}
+
+// tracePath follows the previous links back from the goal to the start and
+// returns the path in order from the start.
+func tracePath(previous map[Cell]Cell, from Cell, to Cell) []Cell {
+	path := []Cell{to}
+	for c := to; c != from; {
+		c = previous[c]
+		path = append(path, c)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// cellQueueItem is a cell in a cellQueue.
+type cellQueueItem struct {
+	cell     Cell
+	priority float64
+	// order is the insertion order, which breaks ties between equal priorities
+	// so results are deterministic.
+	order int
+}
+
+// cellQueue is a min-priority queue of cells, implementing heap.Interface.
+type cellQueue struct {
+	items []cellQueueItem
+	count int
+}
+
+func (q *cellQueue) Len() int {
+	return len(q.items)
+}
+
+func (q *cellQueue) Less(i, j int) bool {
+	if q.items[i].priority != q.items[j].priority {
+		return q.items[i].priority < q.items[j].priority
+	}
+	return q.items[i].order < q.items[j].order
+}
+
+func (q *cellQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+}
+
+func (q *cellQueue) Push(x any) {
+	item := x.(cellQueueItem)
+	item.order = q.count
+	q.count++
+	q.items = append(q.items, item)
+}
+
+func (q *cellQueue) Pop() any {
+	n := len(q.items)
+	item := q.items[n-1]
+	q.items = q.items[:n-1]
+	return item
+}
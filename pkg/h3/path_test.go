@@ -0,0 +1,316 @@
+package h3
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertConnected asserts that each cell in the path is a neighbor of the
+// previous one.
+func assertConnected(t *testing.T, path []Cell) {
+	t.Helper()
+	for i := 1; i < len(path); i++ {
+		d, err := path[i-1].GridDistance(path[i])
+		assert.NoError(t, err)
+		assert.Equal(t, 1, d, "cells %s and %s are not neighbors", path[i-1], path[i])
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	from := Cell(0x872830874ffffff)
+
+	t.Run("same cell", func(t *testing.T) {
+		path, cost, err := ShortestPath(from, from, UniformCost)
+		assert.NoError(t, err)
+		assert.Equal(t, []Cell{from}, path)
+		assert.Equal(t, 0.0, cost)
+	})
+
+	t.Run("uniform cost", func(t *testing.T) {
+		to := Cell(0x87283082affffff)
+		want, err := from.GridDistance(to)
+		assert.NoError(t, err)
+
+		path, cost, err := ShortestPath(from, to, UniformCost)
+		assert.NoError(t, err)
+		assert.Len(t, path, want+1)
+		assert.Equal(t, float64(want), cost)
+		assert.Equal(t, from, path[0])
+		assert.Equal(t, to, path[len(path)-1])
+		assertConnected(t, path)
+	})
+
+	t.Run("around obstacles", func(t *testing.T) {
+		to := Cell(0x872830808ffffff)
+		want, err := from.GridDistance(to)
+		assert.NoError(t, err)
+
+		// Block every cell at distance 2 from the destination except one, so the
+		// path has to squeeze through the gap.
+		ring, distances, err := to.GridDiskDistances(2)
+		assert.NoError(t, err)
+		blocked := CellSet{}
+		for i, c := range ring {
+			if distances[i] == 2 {
+				blocked.Add(c)
+			}
+		}
+		gap := ring[len(ring)-1]
+		delete(blocked, gap)
+		delete(blocked, from)
+
+		path, cost, err := ShortestPath(from, to, AvoidCells(blocked))
+		assert.NoError(t, err)
+		assert.Greater(t, cost, float64(want))
+		assert.Contains(t, path, gap)
+
+		// The cost matches an independent Dijkstra search.
+		reached, err := Reachable(CellSet{from: {}}, 100, AvoidCells(blocked))
+		assert.NoError(t, err)
+		assert.Equal(t, reached[to], cost)
+		assert.Len(t, path, int(cost)+1)
+		assertConnected(t, path)
+		for _, c := range path {
+			assert.False(t, blocked.Contains(c), "path goes through blocked cell %s", c)
+		}
+	})
+
+	t.Run("no path", func(t *testing.T) {
+		to := Cell(0x872830808ffffff)
+		ring, distances, err := to.GridDiskDistances(1)
+		assert.NoError(t, err)
+		blocked := CellSet{}
+		for i, c := range ring {
+			if distances[i] == 1 {
+				blocked.Add(c)
+			}
+		}
+
+		// Refuse steps that wander too far, so the search is bounded.
+		area, err := CellSet{from: {}}.GridDisk(6)
+		assert.NoError(t, err)
+		avoid := AvoidCells(blocked)
+		cost := func(a Cell, b Cell) (float64, bool) {
+			c, ok := avoid(a, b)
+			return c, ok && area.Contains(b)
+		}
+
+		_, _, err = ShortestPath(from, to, cost)
+		assert.ErrorIs(t, err, ErrNoPath)
+	})
+
+	t.Run("great circle heuristic", func(t *testing.T) {
+		to := Cell(0x87283082affffff)
+
+		// Cost each step by the distance between cell centers.
+		cost := func(a Cell, b Cell) (float64, bool) {
+			return GreatCircleHeuristic(1)(a, b), true
+		}
+
+		gridPath, gridCost, err := ShortestPathWithHeuristic(from, to, cost, func(Cell, Cell) float64 { return 0 })
+		assert.NoError(t, err)
+
+		path, pathCost, err := ShortestPathWithHeuristic(from, to, cost, GreatCircleHeuristic(1))
+		assert.NoError(t, err)
+		assert.InDelta(t, gridCost, pathCost, 1e-9)
+		assert.Len(t, path, len(gridPath))
+		assertConnected(t, path)
+	})
+
+	t.Run("grid distance heuristic around a pentagon", func(t *testing.T) {
+		// Where local coordinates fail the heuristic drops to 0, so it isn't
+		// consistent, but paths are still the cheapest.
+		pentagon := Cell(0x820807fffffffff)
+		disk, err := CellSet{pentagon: {}}.GridDisk(4)
+		assert.NoError(t, err)
+		cost := func(_ Cell, to Cell) (float64, bool) {
+			return 1, disk.Contains(to) && to != pentagon
+		}
+		for _, origin := range []Cell{0x820817fffffffff, 0x82095ffffffffff} {
+			reached, err := Reachable(CellSet{origin: {}}, 20, cost)
+			assert.NoError(t, err)
+			for to, want := range reached {
+				_, got, err := ShortestPathWithHeuristic(origin, to, cost, GridDistanceHeuristic(1))
+				assert.NoError(t, err)
+				assert.Equal(t, want, got, "path from %s to %s", origin, to)
+			}
+		}
+	})
+
+	t.Run("sub-unit costs", func(t *testing.T) {
+		// Random obstacles in a disk, with steps costing less than 1.
+		disk, err := CellSet{from: {}}.GridDisk(12)
+		assert.NoError(t, err)
+		cells := disk.Cells()
+		sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+		rng := rand.New(rand.NewSource(1))
+		for trial := 0; trial < 20; trial++ {
+			blocked := CellSet{}
+			for _, c := range cells {
+				if c != from && rng.Float64() < 0.3 {
+					blocked.Add(c)
+				}
+			}
+			cost := func(_ Cell, to Cell) (float64, bool) {
+				return 0.1, disk.Contains(to) && !blocked.Contains(to)
+			}
+
+			reached, err := Reachable(CellSet{from: {}}, 100, cost)
+			assert.NoError(t, err)
+
+			for i := 0; i < 5; i++ {
+				to := cells[rng.Intn(len(cells))]
+				want, ok := reached[to]
+
+				_, got, err := ShortestPath(from, to, cost)
+				_, astar, astarErr := ShortestPathWithHeuristic(from, to, cost, GridDistanceHeuristic(0.1))
+				if !ok {
+					assert.ErrorIs(t, err, ErrNoPath)
+					assert.ErrorIs(t, astarErr, ErrNoPath)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.NoError(t, astarErr)
+				assert.InDelta(t, want, got, 1e-9)
+				assert.InDelta(t, want, astar, 1e-9)
+			}
+		}
+	})
+
+	t.Run("resolution mismatch", func(t *testing.T) {
+		_, _, err := ShortestPath(from, 0x86283080fffffff, UniformCost)
+		assert.ErrorIs(t, err, ErrResolutionMismatch)
+	})
+
+	t.Run("invalid cell", func(t *testing.T) {
+		_, _, err := ShortestPath(from, 0x7fffffffffffffff, UniformCost)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("negative cost", func(t *testing.T) {
+		_, _, err := ShortestPath(from, 0x87283082affffff, func(Cell, Cell) (float64, bool) { return -1, true })
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
+
+func TestGridDistanceHeuristic(t *testing.T) {
+	h := GridDistanceHeuristic(0.5)
+
+	// Around a pentagon, where local coordinates fail, the estimate drops to
+	// 0, so it never exceeds the grid distance.
+	pentagon := Cell(0x820807fffffffff)
+	disk, err := CellSet{pentagon: {}}.GridDisk(3)
+	assert.NoError(t, err)
+	goal := Cell(0x820817fffffffff)
+	estimated := 0
+	for c := range disk {
+		d, err := c.GridDistance(goal)
+		assert.NoError(t, err)
+		if _, err := c.localGridDistance(goal); err != nil {
+			assert.Zero(t, h(c, goal))
+			continue
+		}
+		assert.Equal(t, 0.5*float64(d), h(c, goal))
+		estimated++
+	}
+	assert.Positive(t, estimated)
+
+	// Cells too far apart for local coordinates are estimated at 0, without
+	// searching the grid.
+	sf, err := NewCellFromLatLng(NewLatLng(37.7749, -122.4194), 9)
+	assert.NoError(t, err)
+	tokyo, err := NewCellFromLatLng(NewLatLng(35.6762, 139.6503), 9)
+	assert.NoError(t, err)
+	assert.Zero(t, h(sf, tokyo))
+}
+
+func TestReachable(t *testing.T) {
+	origins := CellSet{0x87283082affffff: {}, 0x872830958ffffff: {}}
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := Reachable(CellSet{}, 1, UniformCost)
+		assert.Error(t, err)
+	})
+
+	t.Run("negative budget", func(t *testing.T) {
+		_, err := Reachable(origins, -1, UniformCost)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("zero budget", func(t *testing.T) {
+		got, err := Reachable(origins, 0, UniformCost)
+		assert.NoError(t, err)
+		assert.Equal(t, map[Cell]float64{0x87283082affffff: 0, 0x872830958ffffff: 0}, got)
+	})
+
+	t.Run("uniform cost matches grid disk distances", func(t *testing.T) {
+		want, err := origins.GridDiskDistances(4)
+		assert.NoError(t, err)
+
+		// A fractional budget doesn't reach any further than its integer part.
+		got, err := Reachable(origins, 4.5, UniformCost)
+		assert.NoError(t, err)
+		assert.Len(t, got, len(want))
+		for c, d := range want {
+			assert.Equal(t, float64(d), got[c], "cost of cell %s", c)
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		origin := Cell(0x87283082affffff)
+
+		// Stepping into the expensive cells costs 5 instead of 1.
+		expensive, err := CellSet{0x872830828ffffff: {}}.GridDisk(1)
+		assert.NoError(t, err)
+		delete(expensive, origin)
+		cost := func(_ Cell, to Cell) (float64, bool) {
+			if expensive.Contains(to) {
+				return 5, true
+			}
+			return 1, true
+		}
+
+		got, err := Reachable(CellSet{origin: {}}, 3, cost)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, got[origin])
+		for c, d := range got {
+			assert.LessOrEqual(t, d, 3.0)
+			assert.False(t, expensive.Contains(c), "expensive cell %s should be out of budget", c)
+		}
+
+		unweighted, err := Reachable(CellSet{origin: {}}, 3, UniformCost)
+		assert.NoError(t, err)
+		assert.Less(t, len(got), len(unweighted))
+	})
+
+	t.Run("obstacles", func(t *testing.T) {
+		origin := Cell(0x87283082affffff)
+		ring, distances, err := origin.GridDiskDistances(1)
+		assert.NoError(t, err)
+		blocked := CellSet{}
+		for i, c := range ring {
+			if distances[i] == 1 {
+				blocked.Add(c)
+			}
+		}
+
+		got, err := Reachable(CellSet{origin: {}}, 10, AvoidCells(blocked))
+		assert.NoError(t, err)
+		assert.Equal(t, map[Cell]float64{origin: 0}, got)
+	})
+
+	t.Run("NaN budget", func(t *testing.T) {
+		_, err := Reachable(CellSet{0x87283082affffff: {}}, math.NaN(), UniformCost)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("invalid origin", func(t *testing.T) {
+		_, err := Reachable(CellSet{0x87283082affffff: {}, 0x7fffffffffffffff: {}}, 3, UniformCost)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,127 @@
+package h3
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// parseQuadkey returns the zoom level and the x and y of the Web Mercator tile
+// of a Bing Maps quadkey.
+func parseQuadkey(qk string) (int, int, int, error) {
+	if len(qk) < 1 || len(qk) > MAX_TILE_ZOOM {
+		return 0, 0, 0, fmt.Errorf("quadkey %q has invalid length: %w", qk, ErrInvalidArgument)
+	}
+
+	var x, y int
+	for i := 0; i < len(qk); i++ {
+		d := qk[i] - '0'
+		if d > 3 {
+			return 0, 0, 0, fmt.Errorf("quadkey %q has invalid digit %q: %w", qk, qk[i], ErrInvalidArgument)
+		}
+		x = x<<1 | int(d&1)
+		y = y<<1 | int(d>>1)
+	}
+
+	return len(qk), x, y, nil
+}
+
+// quadkey returns the Bing Maps quadkey of a Web Mercator tile.
+func quadkey(z int, x int, y int) string {
+	var b strings.Builder
+	for i := z - 1; i >= 0; i-- {
+		b.WriteByte(byte('0' + (x>>i)&1 + 2*((y>>i)&1)))
+	}
+	return b.String()
+}
+
+// tileForLatLng returns the x and y of the Web Mercator tile at zoom z that
+// contains the point. Points beyond the latitude limits of Web Mercator map to
+// the top or bottom row of tiles.
+func tileForLatLng(ll LatLng, z int) (int, int) {
+	n := float64(int(1) << z)
+	x, y := TilePoint(ll, z)
+	clamp := func(v float64) int {
+		return int(math.Max(0, math.Min(n-1, math.Floor(v))))
+	}
+
+	return clamp(x), clamp(y)
+}
+
+// CellsForQuadkey returns the cells at the given resolution that cover the
+// tile of the Bing Maps quadkey, according to the containment mode.
+func CellsForQuadkey(qk string, res int, mode ContainmentMode) (CellSet, error) {
+	if res < 0 || res > MAX_H3_RES {
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	z, x, y, err := parseQuadkey(qk)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := tileBbox(z, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.coverCells(res, mode)
+}
+
+// QuadkeysForCell returns the Bing Maps quadkeys of the tiles at the given
+// level that overlap the cell, in sorted order. As with TileForCell, the
+// top and bottom rows of tiles stand for the earth beyond the latitude limits
+// of Web Mercator, so cells there have the quadkeys of those rows. It returns
+// an error if the cell's bounding box spans more than MAX_CELL_KEYS tiles.
+func QuadkeysForCell(c Cell, level int) ([]string, error) {
+	if level < 1 || level > MAX_TILE_ZOOM {
+		return nil, fmt.Errorf("quadkey level %d out of range: %w", level, ErrInvalidArgument)
+	}
+
+	cellBbox, err := newBboxFromCell(c)
+	if err != nil {
+		return nil, err
+	}
+
+	n := 1 << level
+	west, north := tileForLatLng(LatLng{cellBbox.north, cellBbox.west}, level)
+	east, south := tileForLatLng(LatLng{cellBbox.south, cellBbox.east}, level)
+	if cellBbox.isTransmeridian() || (cellBbox.west == -math.Pi && cellBbox.east == math.Pi) {
+		// Wrap around the antimeridian, visiting each column once.
+		east += n
+		if east-west >= n {
+			west, east = 0, n-1
+		}
+	}
+	if (east-west+1)*(south-north+1) > MAX_CELL_KEYS {
+		return nil, fmt.Errorf("cell %s spans more than %d quadkeys of level %d: %w", c, MAX_CELL_KEYS, level, ErrInvalidArgument)
+	}
+
+	var out []string
+	for x := west; x <= east; x++ {
+		for y := north; y <= south; y++ {
+			b, err := tileBbox(level, x%n, y)
+			if err != nil {
+				return nil, err
+			}
+			// Extend the edge rows to the poles.
+			if y == 0 {
+				b.north = math.Pi / 2
+			}
+			if y == n-1 {
+				b.south = -math.Pi / 2
+			}
+			overlaps, err := b.overlapsCell(c)
+			if err != nil {
+				return nil, err
+			}
+			if overlaps {
+				out = append(out, quadkey(level, x%n, y))
+			}
+		}
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
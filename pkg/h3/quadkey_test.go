@@ -0,0 +1,138 @@
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_quadkey(t *testing.T) {
+	tests := []struct {
+		qk string
+		z  int
+		x  int
+		y  int
+	}{
+		{"0", 1, 0, 0},
+		{"3", 1, 1, 1},
+		{"213", 3, 3, 5},
+		{"023010231", 9, 83, 198},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.qk, quadkey(tt.z, tt.x, tt.y))
+		z, x, y, err := parseQuadkey(tt.qk)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{tt.z, tt.x, tt.y}, []int{z, x, y}, "quadkey %s", tt.qk)
+	}
+
+	for _, qk := range []string{"", "0124", "01a", "0000000000000000000000000000000"} {
+		_, _, _, err := parseQuadkey(qk)
+		assert.ErrorIs(t, err, ErrInvalidArgument, "quadkey %q", qk)
+	}
+}
+
+func TestCellsForQuadkey(t *testing.T) {
+	t.Run("overlapping", func(t *testing.T) {
+		qk := quadkey(10, 163, 395)
+		cells, err := CellsForQuadkey(qk, 7, CONTAINMENT_OVERLAPPING)
+		assert.NoError(t, err)
+		assertCoversTile(t, cells, 10, 163, 395, 7)
+	})
+
+	t.Run("center", func(t *testing.T) {
+		cells, err := CellsForQuadkey(quadkey(10, 163, 395), 7, CONTAINMENT_CENTER)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, cells)
+		for c := range cells {
+			x, y, err := TileForCell(c, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{163, 395}, []int{x, y}, "cell %s", c)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := CellsForQuadkey("0123", MAX_H3_RES+1, CONTAINMENT_CENTER)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForQuadkey("0124", 5, CONTAINMENT_CENTER)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
+
+func TestQuadkeysForCell(t *testing.T) {
+	t.Run("small cell", func(t *testing.T) {
+		c, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 12)
+		assert.NoError(t, err)
+		qks, err := QuadkeysForCell(c, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{quadkey(10, 163, 395)}, qks)
+	})
+
+	t.Run("large cell", func(t *testing.T) {
+		c := mustCellFromString("85283473fffffff")
+		qks, err := QuadkeysForCell(c, 10)
+		assert.NoError(t, err)
+		assert.Greater(t, len(qks), 1)
+		assert.IsIncreasing(t, qks)
+
+		x, y, err := TileForCell(c, 10)
+		assert.NoError(t, err)
+		assert.Contains(t, qks, quadkey(10, x, y))
+	})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		c, err := NewCellFromLatLng(NewLatLng(0, 180), 2)
+		assert.NoError(t, err)
+		qks, err := QuadkeysForCell(c, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0", "1", "2", "3"}, qks)
+	})
+
+	t.Run("beyond mercator", func(t *testing.T) {
+		c, err := NewCellFromLatLng(NewLatLng(89.9, 0), 5)
+		assert.NoError(t, err)
+		// The cell maps to the top row of tiles, like its center.
+		qks, err := QuadkeysForCell(c, 3)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, qks)
+		x, y, err := TileForCell(c, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, y)
+		assert.Contains(t, qks, quadkey(3, x, y))
+		for _, qk := range qks {
+			_, _, y, err := parseQuadkey(qk)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, y)
+		}
+
+		c, err = NewCellFromLatLng(NewLatLng(-89.9, 0), 5)
+		assert.NoError(t, err)
+		qks, err = QuadkeysForCell(c, 10)
+		assert.NoError(t, err)
+		x, y, err = TileForCell(c, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1<<10-1, y)
+		assert.Contains(t, qks, quadkey(10, x, y))
+	})
+
+	t.Run("polar cell", func(t *testing.T) {
+		// The cell spans every longitude, so it overlaps a whole row of tiles.
+		c, err := NewCellFromLatLng(NewLatLng(90, 0), 0)
+		assert.NoError(t, err)
+		qks, err := QuadkeysForCell(c, 3)
+		assert.NoError(t, err)
+		for x := 0; x < 8; x++ {
+			assert.Contains(t, qks, quadkey(3, x, 0))
+		}
+
+		_, err = QuadkeysForCell(c, MAX_TILE_ZOOM)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		c := mustCellFromString("85283473fffffff")
+		_, err := QuadkeysForCell(c, 0)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = QuadkeysForCell(c, MAX_TILE_ZOOM+1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
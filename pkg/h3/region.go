@@ -0,0 +1,363 @@
+package h3
+
+import (
+	"fmt"
+	"math"
+)
+
+// Region is an area of the earth that can be covered with cells: a Rect, a
+// Circle or a Polygon.
+type Region interface {
+	// cover returns the cells at the resolution that overlap the region.
+	cover(res int) (CellSet, error)
+	// prepare validates the region and returns the form used to test cells
+	// against it.
+	prepare() (preparedRegion, error)
+}
+
+// preparedRegion is a validated Region.
+type preparedRegion interface {
+	// cover returns the cells at the resolution that overlap the region.
+	cover(res int) (CellSet, error)
+	// overlapsCell returns whether any part of the cell is in the region.
+	overlapsCell(c Cell) (bool, error)
+	// capRelation returns whether the spherical cap with the center and
+	// radius in radians may intersect the region, and whether the region
+	// certainly contains it.
+	capRelation(center LatLng, radius float64) (intersects bool, contains bool)
+}
+
+// Rect is a region bounded by lines of latitude and longitude. It crosses the
+// antimeridian if the longitude of its south west corner is east of its north
+// east corner.
+type Rect struct {
+	SouthWest LatLng
+	NorthEast LatLng
+}
+
+func (r Rect) cover(res int) (CellSet, error) {
+	p, err := r.prepare()
+	if err != nil {
+		return nil, err
+	}
+	return p.cover(res)
+}
+
+func (r Rect) prepare() (preparedRegion, error) {
+	for _, ll := range []LatLng{r.SouthWest, r.NorthEast} {
+		if math.IsNaN(ll.Latitude()) || math.IsNaN(ll.Longitude()) {
+			return nil, fmt.Errorf("rect corner is NaN: %w", ErrInvalidArgument)
+		}
+		if math.Abs(ll.Latitude()) > math.Pi/2 {
+			return nil, fmt.Errorf("rect latitude %g out of range: %w", rad2deg(ll.Latitude()), ErrInvalidArgument)
+		}
+	}
+	if r.SouthWest.Latitude() > r.NorthEast.Latitude() {
+		return nil, fmt.Errorf("rect south is north of its north: %w", ErrInvalidArgument)
+	}
+
+	return bbox{
+		north: r.NorthEast.Latitude(),
+		south: r.SouthWest.Latitude(),
+		east:  r.NorthEast.Longitude(),
+		west:  r.SouthWest.Longitude(),
+	}, nil
+}
+
+// Circle is the region within a great circle distance of a center point.
+type Circle struct {
+	Center   LatLng
+	RadiusKm float64
+}
+
+func (r Circle) cover(res int) (CellSet, error) {
+	p, err := r.prepare()
+	if err != nil {
+		return nil, err
+	}
+	return p.cover(res)
+}
+
+func (r Circle) prepare() (preparedRegion, error) {
+	if r.RadiusKm < 0 || math.IsNaN(r.RadiusKm) {
+		return nil, fmt.Errorf("circle radius %f is negative: %w", r.RadiusKm, ErrInvalidArgument)
+	}
+	return sphericalCap{center: r.Center, radius: r.RadiusKm / EARTH_RADIUS_KM}, nil
+}
+
+// sphericalCap is the region within a great circle distance in radians of a
+// center point.
+type sphericalCap struct {
+	center LatLng
+	radius float64
+}
+
+func (s sphericalCap) cover(res int) (CellSet, error) {
+	start, err := NewCellFromLatLng(s.center, res)
+	if err != nil {
+		return nil, err
+	}
+	return floodCells([]Cell{start}, s.overlapsCell)
+}
+
+func (s sphericalCap) overlapsCell(c Cell) (bool, error) {
+	return cellWithinDistance(c, s.center, s.radius, CONTAINMENT_OVERLAPPING)
+}
+
+func (s sphericalCap) capRelation(center LatLng, radius float64) (bool, bool) {
+	d := s.center.greatCircleDistanceRads(center)
+	return d <= s.radius+radius, d+radius <= s.radius
+}
+
+// newCapOutline returns the bounding box of a spherical cap in the plane of
+// longitude and latitude, as an outline with longitudes relative to refLng.
+// Caps around a pole are polar outlines.
+func newCapOutline(center LatLng, radius float64, refLng float64) cellOutline {
+	lat := center.Latitude()
+	out := cellOutline{
+		south:  math.Max(lat-radius, -M_PI_2),
+		north:  math.Min(lat+radius, M_PI_2),
+		center: center,
+	}
+	if lat+radius >= M_PI_2 || lat-radius <= -M_PI_2 {
+		out.polar = true
+		return out
+	}
+
+	halfWidth := math.Asin(math.Min(1, math.Sin(radius)/math.Cos(lat)))
+	lng := constrainLng(center.Longitude() - refLng)
+	out.points = [][2]float64{
+		{lng - halfWidth, out.south},
+		{lng + halfWidth, out.south},
+		{lng + halfWidth, out.north},
+		{lng - halfWidth, out.north},
+	}
+	return out
+}
+
+// arcDistanceRads returns the great circle distance in radians from the point
+// to the nearest point of the shorter great circle arc between a and b.
+func (l LatLng) arcDistanceRads(a LatLng, b LatLng) float64 {
+	p := newVec3dFromLatLng(l)
+	va := newVec3dFromLatLng(a)
+	vb := newVec3dFromLatLng(b)
+
+	n := va.cross(vb)
+	norm := math.Sqrt(n.dot(n))
+	if norm > EPSILON {
+		n = vec3d{n.x / norm, n.y / norm, n.z / norm}
+
+		// The nearest point of the great circle is the projection of the point
+		// onto its plane; use it if it lies between a and b.
+		s := p.dot(n)
+		proj := vec3d{p.x - s*n.x, p.y - s*n.y, p.z - s*n.z}
+		if va.cross(proj).dot(n) >= 0 && proj.cross(vb).dot(n) >= 0 {
+			return math.Asin(math.Min(1, math.Abs(s)))
+		}
+	}
+
+	return math.Min(l.greatCircleDistanceRads(a), l.greatCircleDistanceRads(b))
+}
+
+// Polygon is a region bounded by an outer ring, with optional holes, in the
+// same form as the polygons of CellSet.Outline. Edges are straight lines in the
+// plane of longitude and latitude, and polygons may cross the antimeridian but
+// must span less than half of the longitudes.
+type Polygon struct {
+	// Rings are the outer ring followed by the rings of any holes. Rings
+	// needn't be closed.
+	Rings [][]LatLng
+}
+
+func (r Polygon) cover(res int) (CellSet, error) {
+	p, err := r.prepare()
+	if err != nil {
+		return nil, err
+	}
+	return p.cover(res)
+}
+
+func (r Polygon) prepare() (preparedRegion, error) {
+	if len(r.Rings) == 0 {
+		return nil, fmt.Errorf("polygon has no rings: %w", ErrInvalidArgument)
+	}
+
+	// Unwrap the rings around the first vertex, so that polygons crossing the
+	// antimeridian are continuous.
+	out := planarPolygon{
+		rings:  make([][][2]float64, len(r.Rings)),
+		outer:  r.Rings[0],
+		refLng: r.Rings[0][0].Longitude(),
+		south:  M_PI_2,
+		north:  -M_PI_2,
+	}
+	for i, ring := range r.Rings {
+		if len(ring) < 3 {
+			return nil, fmt.Errorf("polygon ring %d has %d vertices: %w", i, len(ring), ErrInvalidArgument)
+		}
+		out.rings[i] = make([][2]float64, len(ring))
+		lng := constrainLng(ring[0].Longitude() - out.refLng)
+		for j, v := range ring {
+			if j > 0 {
+				lng += constrainLng(v.Longitude() - ring[j-1].Longitude())
+			}
+			out.rings[i][j] = [2]float64{lng, v.Latitude()}
+			out.south = math.Min(out.south, v.Latitude())
+			out.north = math.Max(out.north, v.Latitude())
+		}
+	}
+	return out, nil
+}
+
+// planarPolygon is a Polygon with its rings unwrapped in the plane of
+// longitude and latitude, relative to refLng.
+type planarPolygon struct {
+	rings        [][][2]float64
+	outer        []LatLng
+	refLng       float64
+	south, north float64
+}
+
+func (p planarPolygon) cover(res int) (CellSet, error) {
+	var starts []Cell
+	for _, v := range p.outer {
+		c, err := NewCellFromLatLng(v, res)
+		if err != nil {
+			return nil, err
+		}
+		starts = append(starts, c)
+	}
+	return floodCells(starts, p.overlapsCell)
+}
+
+func (p planarPolygon) overlapsCell(c Cell) (bool, error) {
+	outline, err := newCellOutline(c, p.refLng)
+	if err != nil {
+		return false, err
+	}
+	return p.overlapsOutline(outline), nil
+}
+
+func (p planarPolygon) capRelation(center LatLng, radius float64) (bool, bool) {
+	outline := newCapOutline(center, radius, p.refLng)
+	return p.overlapsOutline(outline), p.containsOutline(outline)
+}
+
+// contains returns whether the point is inside the polygon, by the even-odd
+// rule.
+func (p planarPolygon) contains(point [2]float64) bool {
+	contains := false
+	for _, ring := range p.rings {
+		if pointInRing(ring, point) {
+			contains = !contains
+		}
+	}
+	return contains
+}
+
+// localPoints returns the points of the outline, shifted by whole turns to be
+// near the polygon.
+func (p planarPolygon) localPoints(outline cellOutline) [][2]float64 {
+	shift := 0.0
+	if len(outline.points) > 0 {
+		shift = M_2PI * math.Round((p.rings[0][0][0]-outline.points[0][0])/M_2PI)
+	}
+	points := make([][2]float64, len(outline.points))
+	for i, v := range outline.points {
+		points[i] = [2]float64{v[0] + shift, v[1]}
+	}
+	return points
+}
+
+// crosses returns whether a vertex of the polygon is inside the points, or an
+// edge of the polygon crosses theirs.
+func (p planarPolygon) crosses(points [][2]float64) bool {
+	for _, ring := range p.rings {
+		for i, v := range ring {
+			if pointInRing(points, v) {
+				return true
+			}
+			w := ring[(i+1)%len(ring)]
+			for j, a := range points {
+				if segmentsIntersect(v, w, a, points[(j+1)%len(points)]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (p planarPolygon) overlapsOutline(outline cellOutline) bool {
+	if outline.polar {
+		// Conservatively treat outlines around the poles as overlapping if
+		// they share any latitudes.
+		return outline.north >= p.south && outline.south <= p.north
+	}
+
+	points := p.localPoints(outline)
+	for _, v := range points {
+		if p.contains(v) {
+			return true
+		}
+	}
+	return p.crosses(points)
+}
+
+// containsOutline returns whether the whole outline is inside the polygon.
+func (p planarPolygon) containsOutline(outline cellOutline) bool {
+	if outline.polar {
+		return false
+	}
+
+	points := p.localPoints(outline)
+	for _, v := range points {
+		if !p.contains(v) {
+			return false
+		}
+	}
+	return !p.crosses(points)
+}
+
+// floodCells returns the cells for which overlaps is true, flood filling
+// outwards from the start cells through overlapping cells. The start cells
+// should each contain a point of the region.
+func floodCells(starts []Cell, overlaps func(c Cell) (bool, error)) (CellSet, error) {
+	out := make(CellSet)
+	visited := make(CellSet, len(starts))
+	var queue []Cell
+	for _, c := range starts {
+		if !visited.Contains(c) {
+			visited.Add(c)
+			queue = append(queue, c)
+		}
+	}
+
+	var neighbors []Cell
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		ok, err := overlaps(c)
+		if err != nil {
+			return nil, fmt.Errorf("error checking overlap of cell %s: %w", c, err)
+		}
+		if !ok {
+			continue
+		}
+		out.Add(c)
+
+		neighbors, err = c.appendNeighbors(neighbors[:0])
+		if err != nil {
+			return nil, fmt.Errorf("error getting neighbors for cell %s: %w", c, err)
+		}
+		for _, n := range neighbors {
+			if !visited.Contains(n) {
+				visited.Add(n)
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,150 @@
+package h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatLng_arcDistanceRads(t *testing.T) {
+	a := NewLatLng(0, 0)
+	b := NewLatLng(0, 10)
+
+	tests := []struct {
+		name string
+		p    LatLng
+		want float64
+	}{
+		{"above the middle", NewLatLng(5, 5), deg2rad(5)},
+		{"below the middle", NewLatLng(-3, 2), deg2rad(3)},
+		{"on the arc", NewLatLng(0, 7), 0},
+		{"past the end", NewLatLng(0, 20), deg2rad(10)},
+		{"before the start", NewLatLng(1, -1), NewLatLng(1, -1).greatCircleDistanceRads(a)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.p.arcDistanceRads(a, b), 1e-9)
+		})
+	}
+
+	t.Run("degenerate arc", func(t *testing.T) {
+		assert.InDelta(t, deg2rad(2), NewLatLng(2, 0).arcDistanceRads(a, a), 1e-9)
+	})
+}
+
+func TestRect_cover(t *testing.T) {
+	r := Rect{SouthWest: NewLatLng(37.6, -122.6), NorthEast: NewLatLng(37.9, -122.2)}
+	cells, err := r.cover(6)
+	assert.NoError(t, err)
+
+	b := bbox{north: deg2rad(37.9), south: deg2rad(37.6), east: deg2rad(-122.2), west: deg2rad(-122.6)}
+	want, err := b.coverCells(6, CONTAINMENT_OVERLAPPING)
+	assert.NoError(t, err)
+	assert.Equal(t, want, cells)
+
+	_, err = Rect{SouthWest: r.NorthEast, NorthEast: r.SouthWest}.cover(6)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Rect{SouthWest: NewLatLng(math.NaN(), -122.6), NorthEast: r.NorthEast}.cover(6)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Rect{SouthWest: r.SouthWest, NorthEast: NewLatLng(37.9, math.NaN())}.cover(6)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Rect{SouthWest: NewLatLng(-91, -122.6), NorthEast: r.NorthEast}.cover(6)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Rect{SouthWest: r.SouthWest, NorthEast: NewLatLng(90.5, -122.2)}.cover(6)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = RegionCoverer{MinRes: 0, MaxRes: 3, MaxCells: 8}.Covering(Rect{SouthWest: NewLatLng(math.NaN(), 0), NorthEast: r.NorthEast})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestCircle_cover(t *testing.T) {
+	center := NewLatLng(37.775938728915946, -122.41795063018799)
+	const radiusKm = 5.0
+	const res = 8
+
+	cells, err := Circle{Center: center, RadiusKm: radiusKm}.cover(res)
+	assert.NoError(t, err)
+
+	// Every cell with its center in the circle is included, and no cell is
+	// further than a cell's size outside it.
+	disk, err := NewCellSetFromCells(cells.Cells()).GridDisk(2)
+	assert.NoError(t, err)
+	for c := range disk {
+		ll, err := c.LatLng()
+		assert.NoError(t, err)
+		distanceKm := ll.greatCircleDistanceRads(center) * EARTH_RADIUS_KM
+		if distanceKm <= radiusKm {
+			assert.True(t, cells.Contains(c), "cell %s at %f km is missing", c, distanceKm)
+		}
+		if cells.Contains(c) {
+			assert.Less(t, distanceKm, radiusKm+1)
+		}
+	}
+
+	t.Run("zero radius", func(t *testing.T) {
+		cells, err := Circle{Center: center, RadiusKm: 0}.cover(res)
+		assert.NoError(t, err)
+		assert.Len(t, cells, 1)
+	})
+
+	t.Run("negative radius", func(t *testing.T) {
+		_, err := Circle{Center: center, RadiusKm: -1}.cover(res)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
+
+func TestPolygon_cover(t *testing.T) {
+	rect := func(south, west, north, east float64) []LatLng {
+		return []LatLng{
+			NewLatLng(south, west),
+			NewLatLng(south, east),
+			NewLatLng(north, east),
+			NewLatLng(north, west),
+		}
+	}
+
+	t.Run("same as rect", func(t *testing.T) {
+		tests := []struct {
+			name                     string
+			south, west, north, east float64
+			res                      int
+		}{
+			{"city", 37.6, -122.6, 37.9, -122.2, 6},
+			{"antimeridian", -5, 175, 5, -175, 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cells, err := Polygon{Rings: [][]LatLng{rect(tt.south, tt.west, tt.north, tt.east)}}.cover(tt.res)
+				assert.NoError(t, err)
+				want, err := Rect{SouthWest: NewLatLng(tt.south, tt.west), NorthEast: NewLatLng(tt.north, tt.east)}.cover(tt.res)
+				assert.NoError(t, err)
+				assert.Equal(t, want, cells)
+			})
+		}
+	})
+
+	t.Run("hole", func(t *testing.T) {
+		outer := rect(37.6, -122.6, 37.9, -122.2)
+		hole := rect(37.7, -122.5, 37.8, -122.3)
+		cells, err := Polygon{Rings: [][]LatLng{outer, hole}}.cover(7)
+		assert.NoError(t, err)
+
+		inHole, err := Rect{SouthWest: hole[0], NorthEast: hole[2]}.cover(7)
+		assert.NoError(t, err)
+		holeCenter, err := NewCellFromLatLng(NewLatLng(37.75, -122.4), 7)
+		assert.NoError(t, err)
+		assert.True(t, inHole.Contains(holeCenter))
+		assert.False(t, cells.Contains(holeCenter))
+
+		edge, err := NewCellFromLatLng(hole[0], 7)
+		assert.NoError(t, err)
+		assert.True(t, cells.Contains(edge))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Polygon{}.cover(5)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = Polygon{Rings: [][]LatLng{{NewLatLng(0, 0), NewLatLng(1, 1)}}}.cover(5)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,103 @@
+package h3
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// MAX_TILE_ZOOM is the maximum zoom level of Web Mercator XYZ tiles
+	// supported by the tile functions here and in pkg/mvt.
+	MAX_TILE_ZOOM = 30
+
+	// MAX_MERCATOR_LAT_RADS is the latitude in radians at which Web Mercator
+	// tiles end, north and south.
+	MAX_MERCATOR_LAT_RADS = 1.4844222297453324
+
+	// MAX_TILE_CELLS is the most cells that CellsForTile returns, as
+	// estimated from the area of the tile. Fine resolutions at low zoom levels
+	// exceed it.
+	MAX_TILE_CELLS = 1 << 20
+)
+
+// tileBbox returns the bounding box of the Web Mercator XYZ tile.
+func tileBbox(z int, x int, y int) (bbox, error) {
+	if z < 0 || z > MAX_TILE_ZOOM {
+		return bbox{}, fmt.Errorf("zoom %d out of range: %w", z, ErrInvalidArgument)
+	}
+
+	n := 1 << z
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return bbox{}, fmt.Errorf("tile %d/%d/%d out of range: %w", z, x, y, ErrInvalidArgument)
+	}
+
+	northWest := TileLatLng(z, float64(x), float64(y))
+	southEast := TileLatLng(z, float64(x+1), float64(y+1))
+	return bbox{
+		north: northWest.Latitude(),
+		south: southEast.Latitude(),
+		east:  southEast.Longitude(),
+		west:  northWest.Longitude(),
+	}, nil
+}
+
+// TilePoint returns the position of the point in Web Mercator XYZ tile units at
+// zoom z: the integer parts are the x and y of the tile containing the point,
+// and the fractional parts its position within the tile. Latitudes are clamped
+// to the limits of Web Mercator. Longitudes beyond [-π, π] are projected
+// linearly, onto the adjacent copies of the world.
+func TilePoint(ll LatLng, z int) (float64, float64) {
+	n := float64(uint64(1) << z)
+	lat := math.Max(-MAX_MERCATOR_LAT_RADS, math.Min(MAX_MERCATOR_LAT_RADS, ll.Latitude()))
+	x := (ll.Longitude() + math.Pi) / M_2PI * n
+	y := (1 - math.Asinh(math.Tan(lat))/math.Pi) / 2 * n
+	return x, y
+}
+
+// TileLatLng returns the point at the given position in Web Mercator XYZ tile
+// units at zoom z. It is the inverse of TilePoint.
+func TileLatLng(z int, x float64, y float64) LatLng {
+	n := float64(uint64(1) << z)
+	return NewLatLngRads(math.Atan(math.Sinh(math.Pi*(1-2*y/n))), x/n*M_2PI-math.Pi)
+}
+
+// CellsForTile returns the cells at the given resolution that cover the Web
+// Mercator XYZ tile, i.e. every cell that overlaps the tile's bounding box.
+// Cells on the edges of the tile extend past it. It returns an error if the
+// tile holds more than about MAX_TILE_CELLS cells.
+func CellsForTile(z int, x int, y int, res int) (CellSet, error) {
+	if res < 0 || res > MAX_H3_RES {
+		return nil, fmt.Errorf("resolution %d out of range: %w", res, ErrInvalidArgument)
+	}
+
+	b, err := tileBbox(z, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	// Estimate the number of cells from the tile's share of the earth's area,
+	// before making any of them.
+	share := b.widthRads() * (math.Sin(b.north) - math.Sin(b.south)) / (4 * math.Pi)
+	if share*float64(getNumCellsAtResolution(res)) > MAX_TILE_CELLS {
+		return nil, fmt.Errorf("tile %d/%d/%d has more than %d cells at resolution %d: %w", z, x, y, MAX_TILE_CELLS, res, ErrInvalidArgument)
+	}
+
+	return b.coverCells(res, CONTAINMENT_OVERLAPPING)
+}
+
+// TileForCell returns the x and y of the Web Mercator XYZ tile at zoom z that
+// contains the center of the cell. Cells beyond the latitude limits of Web
+// Mercator map to the top or bottom row of tiles.
+func TileForCell(c Cell, z int) (int, int, error) {
+	if z < 0 || z > MAX_TILE_ZOOM {
+		return 0, 0, fmt.Errorf("zoom %d out of range: %w", z, ErrInvalidArgument)
+	}
+
+	center, err := c.LatLng()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	x, y := tileForLatLng(center, z)
+	return x, y, nil
+}
@@ -0,0 +1,149 @@
+package h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileForCell(t *testing.T) {
+	c, err := NewCellFromLatLng(NewLatLng(37.775938728915946, -122.41795063018799), 9)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		z     int
+		wantX int
+		wantY int
+	}{
+		{0, 0, 0},
+		{1, 0, 0},
+		{10, 163, 395},
+		{16, 10482, 25330},
+	}
+	for _, tt := range tests {
+		x, y, err := TileForCell(c, tt.z)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.wantX, x, "x at zoom %d", tt.z)
+		assert.Equal(t, tt.wantY, y, "y at zoom %d", tt.z)
+	}
+
+	t.Run("beyond mercator", func(t *testing.T) {
+		pole, err := NewCellFromLatLng(NewLatLng(90, 0), 5)
+		assert.NoError(t, err)
+		_, y, err := TileForCell(pole, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, y)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := TileForCell(c, -1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, _, err = TileForCell(c, MAX_TILE_ZOOM+1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, _, err = TileForCell(0x7fffffffffffffff, 1)
+		assert.Error(t, err)
+	})
+}
+
+// assertCoversTile asserts that the cells cover a grid of points across the
+// tile, and that they don't stray further than the neighboring tiles.
+func assertCoversTile(t *testing.T, cells CellSet, z int, x int, y int, res int) {
+	t.Helper()
+	b, err := tileBbox(z, x, y)
+	assert.NoError(t, err)
+
+	const steps = 20
+	for i := 0; i <= steps; i++ {
+		for j := 0; j <= steps; j++ {
+			p := LatLng{
+				b.south + (b.north-b.south)*float64(i)/steps,
+				constrainLng(b.west + b.widthRads()*float64(j)/steps),
+			}
+			c, err := NewCellFromLatLng(p, res)
+			assert.NoError(t, err)
+			assert.True(t, cells.Contains(c), "cell %s at %v is missing", c, p)
+		}
+	}
+
+	n := 1 << z
+	for c := range cells {
+		cx, cy, err := TileForCell(c, z)
+		assert.NoError(t, err)
+		dx := abs(cx - x)
+		dx = min(dx, n-dx)
+		assert.LessOrEqual(t, dx, 1, "cell %s is in tile %d/%d/%d", c, z, cx, cy)
+		assert.LessOrEqual(t, abs(cy-y), 1, "cell %s is in tile %d/%d/%d", c, z, cx, cy)
+	}
+}
+
+func TestCellsForTile(t *testing.T) {
+	tests := []struct {
+		name string
+		z    int
+		x    int
+		y    int
+		res  int
+	}{
+		{"city", 10, 163, 395, 7},
+		{"fine", 14, 2620, 6332, 10},
+		{"antimeridian west", 3, 0, 3, 3},
+		{"antimeridian east", 3, 7, 4, 3},
+		{"top row", 2, 1, 0, 2},
+		{"coarse cells in a small tile", 12, 655, 1583, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells, err := CellsForTile(tt.z, tt.x, tt.y, tt.res)
+			assert.NoError(t, err)
+			assertCoversTile(t, cells, tt.z, tt.x, tt.y, tt.res)
+		})
+	}
+
+	t.Run("whole world", func(t *testing.T) {
+		cells, err := CellsForTile(0, 0, 0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, cells, NUM_BASE_CELLS)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := CellsForTile(1, 2, 0, 5)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForTile(1, 0, -1, 5)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForTile(MAX_TILE_ZOOM+1, 0, 0, 5)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForTile(1, 0, 0, MAX_H3_RES+1)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+
+	t.Run("too many cells", func(t *testing.T) {
+		_, err := CellsForTile(0, 0, 0, MAX_H3_RES)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = CellsForTile(10, 163, 395, MAX_H3_RES)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+
+		// The whole world is covered at coarse resolutions.
+		cells, err := CellsForTile(0, 0, 0, 3)
+		assert.NoError(t, err)
+		assert.Greater(t, len(cells), 30000)
+	})
+}
+
+func TestTilePoint(t *testing.T) {
+	ll := NewLatLng(37.7749, -122.4194)
+	x, y := TilePoint(ll, 10)
+	assert.Equal(t, 163.0, math.Floor(x))
+	assert.Equal(t, 395.0, math.Floor(y))
+
+	back := TileLatLng(10, x, y)
+	assert.InDelta(t, ll.Latitude(), back.Latitude(), 1e-12)
+	assert.InDelta(t, ll.Longitude(), back.Longitude(), 1e-12)
+
+	// Poles are clamped to the edges of the map.
+	_, y = TilePoint(NewLatLng(90, 0), 3)
+	assert.InDelta(t, 0, y, 1e-9)
+	_, y = TilePoint(NewLatLng(-90, 0), 3)
+	assert.InDelta(t, 8, y, 1e-9)
+	assert.InDelta(t, MAX_MERCATOR_LAT_RADS, TileLatLng(3, 0, 0).Latitude(), 1e-12)
+}
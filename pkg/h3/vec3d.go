@@ -26,3 +26,17 @@ func newVec3dFromLatLng(l LatLng) vec3d {
 		y: math.Sin(l.Longitude()) * r,
 	}
 }
+
+// dot returns the dot product of two vectors.
+func (v vec3d) dot(v2 vec3d) float64 {
+	return v.x*v2.x + v.y*v2.y + v.z*v2.z
+}
+
+// cross returns the cross product of two vectors.
+func (v vec3d) cross(v2 vec3d) vec3d {
+	return vec3d{
+		x: v.y*v2.z - v.z*v2.y,
+		y: v.z*v2.x - v.x*v2.z,
+		z: v.x*v2.y - v.y*v2.x,
+	}
+}
@@ -0,0 +1,215 @@
+package h3
+
+import (
+	"math"
+	"sort"
+)
+
+// VERTEX_TOLERANCE_RADS is the distance in radians within which two cell
+// vertices are considered the same point (~0.6mm), which is well below the
+// edge length of the finest cells.
+const VERTEX_TOLERANCE_RADS = 1e-10
+
+// vertexKey is the cell of a grid of VERTEX_TOLERANCE_RADS squares that a
+// vertex falls in, used to find vertices computed from different cells.
+type vertexKey [2]int64
+
+// vertexGraph is a directed graph of cell boundary edges. Adding the
+// counter-clockwise boundaries of a set of cells leaves only the edges on the
+// outline of the set, because edges shared by two cells are added in both
+// directions and cancel each other out.
+type vertexGraph struct {
+	verts []LatLng
+	ids   map[vertexKey]int
+	edges map[[2]int]struct{}
+}
+
+func newVertexGraph(numCells int) *vertexGraph {
+	return &vertexGraph{
+		ids:   make(map[vertexKey]int, numCells*2),
+		edges: make(map[[2]int]struct{}, numCells),
+	}
+}
+
+func newVertexKey(ll LatLng) vertexKey {
+	return vertexKey{
+		int64(math.Round(ll.Latitude() / VERTEX_TOLERANCE_RADS)),
+		int64(math.Round(ll.Longitude() / VERTEX_TOLERANCE_RADS)),
+	}
+}
+
+// vertexID returns the id of the vertex at the given point, adding it to the
+// graph if it isn't already there.
+func (g *vertexGraph) vertexID(ll LatLng) int {
+	// The same vertex computed from two cells may differ in the last bits, and
+	// land on either side of a grid line, so search the neighboring squares
+	// too. Longitudes of ±π are the same meridian.
+	candidates := []LatLng{ll}
+	if ll.Longitude() > math.Pi-VERTEX_TOLERANCE_RADS {
+		candidates = append(candidates, LatLng{ll.Latitude(), ll.Longitude() - M_2PI})
+	} else if ll.Longitude() < -math.Pi+VERTEX_TOLERANCE_RADS {
+		candidates = append(candidates, LatLng{ll.Latitude(), ll.Longitude() + M_2PI})
+	}
+
+	for _, candidate := range candidates {
+		key := newVertexKey(candidate)
+		for di := int64(-1); di <= 1; di++ {
+			for dj := int64(-1); dj <= 1; dj++ {
+				id, ok := g.ids[vertexKey{key[0] + di, key[1] + dj}]
+				if !ok {
+					continue
+				}
+				v := g.verts[id]
+				if math.Abs(v.Latitude()-candidate.Latitude()) < VERTEX_TOLERANCE_RADS &&
+					math.Abs(v.Longitude()-candidate.Longitude()) < VERTEX_TOLERANCE_RADS {
+					return id
+				}
+			}
+		}
+	}
+
+	id := len(g.verts)
+	g.verts = append(g.verts, ll)
+	g.ids[newVertexKey(ll)] = id
+	return id
+}
+
+// addBoundary adds the edges of a counter-clockwise cell boundary, cancelling
+// out any edges already added in the opposite direction.
+func (g *vertexGraph) addBoundary(boundary []LatLng) {
+	first := g.vertexID(boundary[0])
+	from := first
+	for i := 1; i <= len(boundary); i++ {
+		to := first
+		if i < len(boundary) {
+			to = g.vertexID(boundary[i])
+		}
+
+		reverse := [2]int{to, from}
+		if _, ok := g.edges[reverse]; ok {
+			delete(g.edges, reverse)
+		} else {
+			g.edges[[2]int{from, to}] = struct{}{}
+		}
+
+		from = to
+	}
+}
+
+// loops chains the remaining edges into closed loops. Every vertex of the hex
+// grid is shared by exactly three cells, so each vertex on the outline has
+// exactly one outgoing edge.
+func (g *vertexGraph) loops() [][]LatLng {
+	next := make(map[int]int, len(g.edges))
+	for e := range g.edges {
+		next[e[0]] = e[1]
+	}
+
+	starts := make([]int, 0, len(next))
+	for from := range next {
+		starts = append(starts, from)
+	}
+	sort.Ints(starts)
+
+	var loops [][]LatLng
+	for _, start := range starts {
+		if _, ok := next[start]; !ok {
+			// already part of a loop
+			continue
+		}
+
+		var loop []LatLng
+		for v := start; ; {
+			to, ok := next[v]
+			if !ok {
+				break
+			}
+			delete(next, v)
+			loop = append(loop, g.verts[v])
+			v = to
+		}
+		loops = append(loops, loop)
+	}
+
+	return loops
+}
+
+// polygons groups the outline loops into polygons, each a counter-clockwise
+// outer loop followed by the clockwise loops of the holes inside it.
+func (g *vertexGraph) polygons() [][][]LatLng {
+	var polygons [][][]LatLng
+	var areas []float64
+	var holes [][]LatLng
+	for _, loop := range g.loops() {
+		area := loopArea(loop)
+		if area < 0 {
+			holes = append(holes, loop)
+			continue
+		}
+		polygons = append(polygons, [][]LatLng{loop})
+		areas = append(areas, area)
+	}
+
+	// Assign each hole to the smallest outer loop containing it, which matters
+	// when there are islands inside holes.
+	for _, hole := range holes {
+		best := -1
+		for i, polygon := range polygons {
+			if (best == -1 || areas[i] < areas[best]) && loopContains(polygon[0], hole[0]) {
+				best = i
+			}
+		}
+
+		if best == -1 {
+			// Shouldn't happen, but don't lose the loop if it does.
+			polygons = append(polygons, [][]LatLng{hole})
+			areas = append(areas, -loopArea(hole))
+			continue
+		}
+		polygons[best] = append(polygons[best], hole)
+	}
+
+	return polygons
+}
+
+// loopArea returns the signed area of the loop on the unit sphere, which is
+// positive for loops that are counter-clockwise when seen from outside the
+// sphere. The loop is split into triangles around the normalized mean of its
+// vertices, so loops around a pole are handled, but loops enclosing more than
+// a hemisphere aren't.
+func loopArea(loop []LatLng) float64 {
+	vertices := make([]vec3d, len(loop))
+	var center vec3d
+	for i, ll := range loop {
+		vertices[i] = newVec3dFromLatLng(ll)
+		center = vec3d{center.x + vertices[i].x, center.y + vertices[i].y, center.z + vertices[i].z}
+	}
+	norm := math.Sqrt(center.dot(center))
+	if norm == 0 {
+		return 0
+	}
+	center = vec3d{center.x / norm, center.y / norm, center.z / norm}
+
+	area := 0.0
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		// The signed solid angle of the triangle (Van Oosterom and Strackee).
+		area += 2 * math.Atan2(center.dot(a.cross(b)), 1+center.dot(a)+a.dot(b)+b.dot(center))
+	}
+	return area
+}
+
+// loopContains returns whether the point is inside the loop, by summing the
+// angles the loop's edges subtend at the point on the sphere: they wind once
+// around points inside the loop, and not at all around points outside it. Like
+// loopArea, this handles loops around a pole.
+func loopContains(loop []LatLng, p LatLng) bool {
+	v := newVec3dFromLatLng(p)
+	winding := 0.0
+	for i := range loop {
+		a := newVec3dFromLatLng(loop[i])
+		b := newVec3dFromLatLng(loop[(i+1)%len(loop)])
+		winding += math.Atan2(v.dot(a.cross(b)), a.dot(b)-v.dot(a)*v.dot(b))
+	}
+	return math.Abs(winding) > math.Pi
+}
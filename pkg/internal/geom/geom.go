@@ -0,0 +1,31 @@
+// Package geom has the polygon helpers shared by the geometry encodings.
+package geom
+
+import "github.com/ziprecruiter/h3-go/pkg/h3"
+
+// NonEmptyRings returns the rings of a polygon without its empty holes. A
+// polygon whose outer ring is empty is empty, and has no rings.
+func NonEmptyRings(rings [][]h3.LatLng) [][]h3.LatLng {
+	if len(rings) == 0 || len(rings[0]) == 0 {
+		return nil
+	}
+	nonEmpty := make([][]h3.LatLng, 0, len(rings))
+	for _, ring := range rings {
+		if len(ring) > 0 {
+			nonEmpty = append(nonEmpty, ring)
+		}
+	}
+	return nonEmpty
+}
+
+// NonEmptyPolygons returns the polygons that aren't empty, with their empty
+// holes removed.
+func NonEmptyPolygons(polygons [][][]h3.LatLng) [][][]h3.LatLng {
+	nonEmpty := make([][][]h3.LatLng, 0, len(polygons))
+	for _, rings := range polygons {
+		if rings = NonEmptyRings(rings); len(rings) > 0 {
+			nonEmpty = append(nonEmpty, rings)
+		}
+	}
+	return nonEmpty
+}
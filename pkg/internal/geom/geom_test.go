@@ -0,0 +1,30 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+var triangle = []h3.LatLng{
+	h3.NewLatLng(0, 0),
+	h3.NewLatLng(0, 1),
+	h3.NewLatLng(1, 1),
+}
+
+func TestNonEmptyRings(t *testing.T) {
+	assert.Nil(t, NonEmptyRings(nil))
+	assert.Nil(t, NonEmptyRings([][]h3.LatLng{{}}))
+	assert.Nil(t, NonEmptyRings([][]h3.LatLng{{}, triangle}))
+	assert.Equal(t, [][]h3.LatLng{triangle, triangle}, NonEmptyRings([][]h3.LatLng{triangle, {}, triangle}))
+}
+
+func TestNonEmptyPolygons(t *testing.T) {
+	assert.Empty(t, NonEmptyPolygons(nil))
+	assert.Empty(t, NonEmptyPolygons([][][]h3.LatLng{{}, {{}}, {{}, triangle}}))
+	assert.Equal(t,
+		[][][]h3.LatLng{{triangle}, {triangle, triangle}},
+		NonEmptyPolygons([][][]h3.LatLng{{triangle}, {}, {triangle, {}, triangle}}),
+	)
+}
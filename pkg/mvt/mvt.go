@@ -0,0 +1,406 @@
+// Package mvt encodes cell-valued data as Mapbox Vector Tiles (MVT, version
+// 2.1 of the specification), so that it can be served directly to map
+// renderers.
+//
+// Each cell becomes a polygon feature, with the cell as its id and the value as
+// a property. Cells are projected to Web Mercator tile coordinates and clipped
+// to the tile plus a buffer. The protobuf is encoded by hand, so the package
+// has no dependencies.
+package mvt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+const (
+	// DEFAULT_EXTENT is the default number of tile coordinate units along each
+	// side of a tile.
+	DEFAULT_EXTENT = 4096
+	// DEFAULT_BUFFER is the default number of tile coordinate units that
+	// geometries extend past the edges of the tile, so that renderers can draw
+	// the edges of cells without seams.
+	DEFAULT_BUFFER = 64
+	// DEFAULT_LAYER_NAME is the default name of the layer of cells.
+	DEFAULT_LAYER_NAME = "cells"
+	// DEFAULT_PROPERTY_NAME is the default name of the value property.
+	DEFAULT_PROPERTY_NAME = "value"
+
+	// MVT geometry commands.
+	commandMoveTo    = 1
+	commandLineTo    = 2
+	commandClosePath = 7
+
+	// MVT geometry type of polygons.
+	geomTypePolygon = 3
+
+	// layerVersion is the version of the MVT specification the layers follow.
+	layerVersion = 2
+)
+
+// ErrInvalidTile is returned by Encode for tiles outside the XYZ tile grid of
+// their zoom level, or at zoom levels beyond h3.MAX_TILE_ZOOM.
+var ErrInvalidTile = errors.New("invalid tile")
+
+// Value is the type of values that can be encoded as feature properties.
+type Value interface {
+	~string | ~bool | ~float32 | ~float64 |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Tile is a Web Mercator tile, in the XYZ scheme: X grows eastwards and Y
+// southwards from the top left tile.
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// Valid returns whether the tile exists at its zoom level, which must be at
+// most h3.MAX_TILE_ZOOM.
+func (t Tile) Valid() bool {
+	if t.Z < 0 || t.Z > h3.MAX_TILE_ZOOM {
+		return false
+	}
+	n := 1 << t.Z
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// Options control how tiles are encoded. Zero values select the defaults.
+type Options struct {
+	// Extent is the number of tile coordinate units along each side of the
+	// tile.
+	Extent int
+	// Buffer is the number of tile coordinate units that geometries extend
+	// past the edges of the tile. Use a negative value for no buffer.
+	Buffer int
+	// LayerName is the name of the layer of cells.
+	LayerName string
+	// PropertyName is the name of the value property of each feature.
+	PropertyName string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Extent <= 0 {
+		o.Extent = DEFAULT_EXTENT
+	}
+	if o.Buffer == 0 {
+		o.Buffer = DEFAULT_BUFFER
+	} else if o.Buffer < 0 {
+		o.Buffer = 0
+	}
+	if o.LayerName == "" {
+		o.LayerName = DEFAULT_LAYER_NAME
+	}
+	if o.PropertyName == "" {
+		o.PropertyName = DEFAULT_PROPERTY_NAME
+	}
+	return o
+}
+
+// Encode returns a vector tile with a single layer, holding a polygon feature
+// for each cell that overlaps the tile. Cells outside the tile (and its buffer)
+// are left out, so values may cover a larger area than the tile.
+func Encode[V Value](values map[h3.Cell]V, tile Tile, opts Options) ([]byte, error) {
+	if !tile.Valid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTile, tile)
+	}
+	opts = opts.withDefaults()
+
+	// Encode features in a fixed order so that tiles are reproducible.
+	cells := make([]h3.Cell, 0, len(values))
+	for c := range values {
+		cells = append(cells, c)
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+	proj := newProjection(tile, opts)
+	var features [][]byte
+	var encodedValues [][]byte
+	valueIndexes := make(map[string]uint32)
+	for _, c := range cells {
+		// Computing boundaries is expensive, so rule out cells that are
+		// clearly away from the tile first.
+		near, err := proj.near(c)
+		if err != nil {
+			return nil, err
+		}
+		if !near {
+			continue
+		}
+
+		boundary, err := c.Boundary()
+		if err != nil {
+			return nil, fmt.Errorf("error getting boundary for cell %s: %w", c, err)
+		}
+
+		ring := proj.ring(boundary)
+		if ring == nil {
+			continue
+		}
+
+		value := encodeValue(values[c])
+		index, ok := valueIndexes[string(value)]
+		if !ok {
+			index = uint32(len(encodedValues))
+			valueIndexes[string(value)] = index
+			encodedValues = append(encodedValues, value)
+		}
+
+		var feature []byte
+		feature = appendVarintField(feature, 1, uint64(c))
+		feature = appendPackedField(feature, 2, []uint32{0, index})
+		feature = appendVarintField(feature, 3, geomTypePolygon)
+		feature = appendPackedField(feature, 4, encodeRing(ring))
+		features = append(features, feature)
+	}
+
+	var layer []byte
+	layer = appendStringField(layer, 1, opts.LayerName)
+	for _, feature := range features {
+		layer = appendBytesField(layer, 2, feature)
+	}
+	layer = appendStringField(layer, 3, opts.PropertyName)
+	for _, value := range encodedValues {
+		layer = appendBytesField(layer, 4, value)
+	}
+	layer = appendVarintField(layer, 5, uint64(opts.Extent))
+	layer = appendVarintField(layer, 15, layerVersion)
+
+	return appendBytesField(nil, 3, layer), nil
+}
+
+// encodeValue encodes a value as an MVT Value message.
+func encodeValue[V Value](v V) []byte {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return appendStringField(nil, 1, rv.String())
+	case reflect.Float32:
+		return appendFloatField(nil, 2, float32(rv.Float()))
+	case reflect.Float64:
+		return appendDoubleField(nil, 3, rv.Float())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendVarintField(nil, 6, zigzag(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return appendVarintField(nil, 5, rv.Uint())
+	default:
+		var b uint64
+		if rv.Bool() {
+			b = 1
+		}
+		return appendVarintField(nil, 7, b)
+	}
+}
+
+// encodeRing encodes a ring in tile coordinates as MVT geometry commands,
+// starting from a cursor at the origin.
+func encodeRing(ring [][2]int64) []uint32 {
+	commands := make([]uint32, 0, 2*len(ring)+3)
+	var cursor [2]int64
+	for i, p := range ring {
+		switch i {
+		case 0:
+			commands = append(commands, commandMoveTo|1<<3)
+		case 1:
+			commands = append(commands, commandLineTo|uint32(len(ring)-1)<<3)
+		}
+		commands = append(commands, uint32(zigzag(p[0]-cursor[0])), uint32(zigzag(p[1]-cursor[1])))
+		cursor = p
+	}
+	return append(commands, commandClosePath|1<<3)
+}
+
+// projection projects points to the coordinates of a tile.
+type projection struct {
+	tile      Tile
+	extent    float64
+	min       float64
+	max       float64
+	centerLng float64
+
+	// The bounds in radians of the tile and its buffer, with the longitudes
+	// as a half width around centerLng.
+	north        float64
+	south        float64
+	halfWidthLng float64
+}
+
+func newProjection(tile Tile, opts Options) projection {
+	buffer := float64(opts.Buffer) / float64(opts.Extent)
+	northWest := h3.TileLatLng(tile.Z, float64(tile.X)-buffer, float64(tile.Y)-buffer)
+	southEast := h3.TileLatLng(tile.Z, float64(tile.X+1)+buffer, float64(tile.Y+1)+buffer)
+	center := h3.TileLatLng(tile.Z, float64(tile.X)+0.5, float64(tile.Y)+0.5)
+	return projection{
+		tile:         tile,
+		extent:       float64(opts.Extent),
+		min:          float64(-opts.Buffer),
+		max:          float64(opts.Extent + opts.Buffer),
+		centerLng:    center.LongitudeDegrees(),
+		north:        northWest.Latitude(),
+		south:        southEast.Latitude(),
+		halfWidthLng: (southEast.Longitude() - northWest.Longitude()) / 2,
+	}
+}
+
+// near returns whether the cell may overlap the tile and its buffer, judging
+// by a circle around the cell's center that contains the cell. It is cheaper
+// than projecting the cell's boundary.
+func (p projection) near(c h3.Cell) (bool, error) {
+	center, err := c.LatLng()
+	if err != nil {
+		return false, fmt.Errorf("error getting center of cell %s: %w", c, err)
+	}
+	edge, err := h3.HexagonEdgeLengthAvgM(c.Resolution())
+	if err != nil {
+		return false, err
+	}
+	// No cell vertex is more than 1.5 average edge lengths from its center.
+	radius := 1.5 * edge / (h3.EARTH_RADIUS_KM * 1000)
+
+	lat := center.Latitude()
+	if lat-radius > p.north || lat+radius < p.south {
+		return false, nil
+	}
+	if math.Abs(lat)+radius >= math.Pi/2 {
+		// The circle contains a pole, so it spans every longitude.
+		return true, nil
+	}
+
+	halfWidth := p.halfWidthLng + radius/math.Cos(math.Abs(lat)+radius)
+	dLng := math.Remainder(center.Longitude()-p.centerLng*math.Pi/180, 2*math.Pi)
+	return math.Abs(dLng) <= halfWidth, nil
+}
+
+// point projects a point, with longitude and latitude in degrees, to tile
+// coordinates.
+func (p projection) point(lng float64, lat float64) [2]float64 {
+	x, y := h3.TilePoint(h3.NewLatLng(lat, lng), p.tile.Z)
+	return [2]float64{
+		(x - float64(p.tile.X)) * p.extent,
+		(y - float64(p.tile.Y)) * p.extent,
+	}
+}
+
+// ring projects a cell boundary to tile coordinates, clips it to the tile and
+// its buffer, and orients it as an MVT exterior ring. It returns nil if
+// nothing is left of the ring.
+func (p projection) ring(boundary []h3.LatLng) [][2]int64 {
+	// Unwrap longitudes so that rings crossing the antimeridian are
+	// continuous, then shift the ring to the copy of the world nearest the
+	// tile.
+	lngs := make([]float64, len(boundary))
+	lngs[0] = boundary[0].LongitudeDegrees()
+	for i := 1; i < len(boundary); i++ {
+		d := boundary[i].LongitudeDegrees() - boundary[i-1].LongitudeDegrees()
+		d -= 360 * math.Round(d/360)
+		lngs[i] = lngs[i-1] + d
+	}
+	shift := 360 * math.Round((p.centerLng-lngs[0])/360)
+
+	points := make([][2]float64, len(boundary))
+	for i, ll := range boundary {
+		points[i] = p.point(lngs[i]+shift, ll.LatitudeDegrees())
+	}
+
+	points = clip(points, p.min, p.max)
+
+	// Round to integer coordinates, dropping points that become duplicates.
+	ring := make([][2]int64, 0, len(points))
+	for _, pt := range points {
+		q := [2]int64{int64(math.Round(pt[0])), int64(math.Round(pt[1]))}
+		if len(ring) > 0 && ring[len(ring)-1] == q {
+			continue
+		}
+		ring = append(ring, q)
+	}
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return nil
+	}
+
+	// Exterior rings must have a positive area in tile coordinates, where y
+	// grows downwards.
+	area := ringArea(ring)
+	if area == 0 {
+		return nil
+	}
+	if area < 0 {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+
+	return ring
+}
+
+// ringArea returns twice the signed area of the ring.
+func ringArea(ring [][2]int64) int64 {
+	var area int64
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+	return area
+}
+
+// clip clips a polygon to the square [min, max] in both dimensions, using the
+// Sutherland-Hodgman algorithm.
+func clip(points [][2]float64, min float64, max float64) [][2]float64 {
+	edges := []struct {
+		axis    int
+		bound   float64
+		keepLow bool
+	}{
+		{0, min, false},
+		{0, max, true},
+		{1, min, false},
+		{1, max, true},
+	}
+
+	for _, edge := range edges {
+		if len(points) == 0 {
+			return nil
+		}
+
+		inside := func(p [2]float64) bool {
+			if edge.keepLow {
+				return p[edge.axis] <= edge.bound
+			}
+			return p[edge.axis] >= edge.bound
+		}
+		intersect := func(a [2]float64, b [2]float64) [2]float64 {
+			t := (edge.bound - a[edge.axis]) / (b[edge.axis] - a[edge.axis])
+			return [2]float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+		}
+
+		in := points
+		points = make([][2]float64, 0, len(in)+4)
+		prev := in[len(in)-1]
+		for _, p := range in {
+			switch {
+			case inside(p) && inside(prev):
+				points = append(points, p)
+			case inside(p):
+				points = append(points, intersect(prev, p), p)
+			case inside(prev):
+				points = append(points, intersect(prev, p))
+			}
+			prev = p
+		}
+	}
+
+	return points
+}
@@ -0,0 +1,259 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+// sfTile is the zoom 10 tile containing San Francisco.
+var sfTile = Tile{Z: 10, X: 163, Y: 395}
+
+// pbField is a decoded protobuf field: either a varint or fixed value, or the
+// bytes of a length-delimited field.
+type pbField struct {
+	value uint64
+	bytes []byte
+}
+
+// decodeMessage decodes a protobuf message into its fields by number.
+func decodeMessage(t *testing.T, b []byte) map[int][]pbField {
+	t.Helper()
+	fields := make(map[int][]pbField)
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		assert.Greater(t, n, 0)
+		b = b[n:]
+
+		var f pbField
+		switch key & 7 {
+		case wireVarint:
+			f.value, n = binary.Uvarint(b)
+			assert.Greater(t, n, 0)
+			b = b[n:]
+		case wireFixed64:
+			f.value = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case wireFixed32:
+			f.value = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(b)
+			assert.Greater(t, n, 0)
+			f.bytes = b[n : n+int(length)]
+			b = b[n+int(length):]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+		fields[int(key>>3)] = append(fields[int(key>>3)], f)
+	}
+	return fields
+}
+
+// decodePacked decodes a packed repeated varint field.
+func decodePacked(t *testing.T, b []byte) []uint64 {
+	t.Helper()
+	var out []uint64
+	for len(b) > 0 {
+		v, n := binary.Uvarint(b)
+		assert.Greater(t, n, 0)
+		out = append(out, v)
+		b = b[n:]
+	}
+	return out
+}
+
+// decodeRing decodes the geometry commands of a single ring polygon.
+func decodeRing(t *testing.T, commands []uint64) [][2]int64 {
+	t.Helper()
+	var ring [][2]int64
+	var cursor [2]int64
+	for i := 0; i < len(commands); {
+		id, count := commands[i]&7, int(commands[i]>>3)
+		i++
+		if id == commandClosePath {
+			assert.Equal(t, 1, count)
+			assert.Equal(t, len(commands), i, "close path must be the last command")
+			continue
+		}
+		for j := 0; j < count; j++ {
+			dx, dy := commands[i], commands[i+1]
+			cursor[0] += int64(dx>>1) ^ -int64(dx&1)
+			cursor[1] += int64(dy>>1) ^ -int64(dy&1)
+			ring = append(ring, cursor)
+			i += 2
+		}
+	}
+	return ring
+}
+
+func TestEncode(t *testing.T) {
+	center, err := h3.NewCellFromLatLng(h3.NewLatLng(37.775938728915946, -122.41795063018799), 8)
+	assert.NoError(t, err)
+	disk, err := h3.CellSet{center: {}}.GridDisk(3)
+	assert.NoError(t, err)
+
+	values := make(map[h3.Cell]float64, len(disk)+1)
+	for c := range disk {
+		values[c] = 1.5
+	}
+	values[center] = 2.5
+	// A cell far away from the tile is left out.
+	values[0x88754e6499fffff] = 3.5
+
+	tile, err := Encode(values, sfTile, Options{})
+	assert.NoError(t, err)
+
+	layers := decodeMessage(t, tile)[3]
+	assert.Len(t, layers, 1)
+	layer := decodeMessage(t, layers[0].bytes)
+
+	assert.Equal(t, "cells", string(layer[1][0].bytes))
+	assert.Equal(t, uint64(4096), layer[5][0].value)
+	assert.Equal(t, uint64(2), layer[15][0].value)
+	assert.Len(t, layer[3], 1)
+	assert.Equal(t, "value", string(layer[3][0].bytes))
+
+	// Values are deduplicated.
+	var decodedValues []float64
+	for _, v := range layer[4] {
+		value := decodeMessage(t, v.bytes)
+		decodedValues = append(decodedValues, math.Float64frombits(value[3][0].value))
+	}
+	assert.ElementsMatch(t, []float64{1.5, 2.5}, decodedValues)
+
+	features := layer[2]
+	assert.Len(t, features, len(disk))
+	for _, f := range features {
+		feature := decodeMessage(t, f.bytes)
+		c := h3.Cell(feature[1][0].value)
+		assert.True(t, disk.Contains(c))
+		assert.Equal(t, uint64(geomTypePolygon), feature[3][0].value)
+
+		tags := decodePacked(t, feature[2][0].bytes)
+		assert.Len(t, tags, 2)
+		assert.Equal(t, uint64(0), tags[0])
+		assert.Equal(t, values[c], decodedValues[tags[1]])
+
+		ring := decodeRing(t, decodePacked(t, feature[4][0].bytes))
+		assert.GreaterOrEqual(t, len(ring), 6)
+		assert.Greater(t, ringArea(ring), int64(0), "exterior rings must have positive area")
+		for _, p := range ring {
+			assert.True(t, p[0] >= -64 && p[0] <= 4096+64 && p[1] >= -64 && p[1] <= 4096+64, "point %v outside the tile", p)
+		}
+	}
+}
+
+func TestEncode_clipping(t *testing.T) {
+	// A coarse cell covers the whole tile around its center, so it gets clipped
+	// to the buffer.
+	c, err := h3.NewCellFromLatLng(h3.NewLatLng(37.775938728915946, -122.41795063018799), 3)
+	assert.NoError(t, err)
+
+	center, err := c.LatLng()
+	assert.NoError(t, err)
+	p := newProjection(Tile{Z: 10}, Options{Extent: 1}).point(center.LongitudeDegrees(), center.LatitudeDegrees())
+	centerTile := Tile{Z: 10, X: int(p[0]), Y: int(p[1])}
+
+	tile, err := Encode(map[h3.Cell]int{c: -7}, centerTile, Options{Extent: 256, Buffer: 8, LayerName: "jobs", PropertyName: "count"})
+	assert.NoError(t, err)
+
+	layer := decodeMessage(t, decodeMessage(t, tile)[3][0].bytes)
+	assert.Equal(t, "jobs", string(layer[1][0].bytes))
+	assert.Equal(t, "count", string(layer[3][0].bytes))
+	assert.Equal(t, zigzag(-7), decodeMessage(t, layer[4][0].bytes)[6][0].value)
+
+	feature := decodeMessage(t, layer[2][0].bytes)
+	ring := decodeRing(t, decodePacked(t, feature[4][0].bytes))
+	assert.ElementsMatch(t, [][2]int64{{-8, -8}, {264, -8}, {264, 264}, {-8, 264}}, ring)
+	assert.Greater(t, ringArea(ring), int64(0))
+}
+
+func TestEncode_antimeridian(t *testing.T) {
+	// The cell straddles the antimeridian, and shows up on tiles on both sides.
+	c, err := h3.NewCellFromLatLng(h3.NewLatLng(0, 180), 2)
+	assert.NoError(t, err)
+
+	for _, tile := range []Tile{{Z: 2, X: 0, Y: 2}, {Z: 2, X: 3, Y: 1}} {
+		encoded, err := Encode(map[h3.Cell]bool{c: true}, tile, Options{})
+		assert.NoError(t, err)
+
+		layer := decodeMessage(t, decodeMessage(t, encoded)[3][0].bytes)
+		assert.Len(t, layer[2], 1, "tile %s", tile)
+		assert.Equal(t, uint64(1), decodeMessage(t, layer[4][0].bytes)[7][0].value)
+
+		feature := decodeMessage(t, layer[2][0].bytes)
+		ring := decodeRing(t, decodePacked(t, feature[4][0].bytes))
+		assert.Greater(t, ringArea(ring), int64(0))
+	}
+}
+
+func Test_projection_near(t *testing.T) {
+	tests := []struct {
+		name   string
+		tile   Tile
+		center h3.LatLng
+		res    int
+		k      int
+	}{
+		{"city", sfTile, h3.NewLatLng(37.7749, -122.4194), 7, 30},
+		{"antimeridian", Tile{Z: 3, X: 7, Y: 3}, h3.NewLatLng(20, 180), 3, 12},
+		{"top row", Tile{Z: 3, X: 2, Y: 0}, h3.NewLatLng(84, -80), 3, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin, err := h3.NewCellFromLatLng(tt.center, tt.res)
+			assert.NoError(t, err)
+			disk, err := h3.CellSet{origin: {}}.GridDisk(tt.k)
+			assert.NoError(t, err)
+
+			// Cells that end up on the tile are never ruled out, and the
+			// prefilter rules out most of the others.
+			proj := newProjection(tt.tile, Options{}.withDefaults())
+			onTile, near := 0, 0
+			for c := range disk {
+				isNear, err := proj.near(c)
+				assert.NoError(t, err)
+				boundary, err := c.Boundary()
+				assert.NoError(t, err)
+				if proj.ring(boundary) != nil {
+					onTile++
+					assert.True(t, isNear, "cell %s", c)
+				}
+				if isNear {
+					near++
+				}
+			}
+			assert.Positive(t, onTile)
+			assert.Less(t, near, len(disk))
+			assert.Less(t, near-onTile, len(disk)-near)
+		})
+	}
+}
+
+func TestEncode_empty(t *testing.T) {
+	tile, err := Encode(map[h3.Cell]string{}, sfTile, Options{})
+	assert.NoError(t, err)
+
+	layer := decodeMessage(t, decodeMessage(t, tile)[3][0].bytes)
+	assert.Empty(t, layer[2])
+	assert.Empty(t, layer[4])
+}
+
+func TestEncode_invalidTile(t *testing.T) {
+	for _, tile := range []Tile{{Z: -1}, {Z: 31}, {Z: 2, X: 4}, {Z: 2, Y: -1}} {
+		_, err := Encode(map[h3.Cell]string{}, tile, Options{})
+		assert.ErrorIs(t, err, ErrInvalidTile, "tile %s", tile)
+	}
+}
+
+func Test_encodeValue(t *testing.T) {
+	type count uint16
+	assert.Equal(t, "x", string(decodeMessage(t, encodeValue("x"))[1][0].bytes))
+	assert.Equal(t, uint64(math.Float32bits(0.5)), decodeMessage(t, encodeValue(float32(0.5)))[2][0].value)
+	assert.Equal(t, uint64(7), decodeMessage(t, encodeValue(count(7)))[5][0].value)
+	assert.Equal(t, uint64(0), decodeMessage(t, encodeValue(false))[7][0].value)
+}
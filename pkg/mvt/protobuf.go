@@ -0,0 +1,60 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// appendTag appends the key of a field with the given number and wire type.
+func appendTag(out []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(out, uint64(field<<3|wireType))
+}
+
+func appendVarintField(out []byte, field int, v uint64) []byte {
+	out = appendTag(out, field, wireVarint)
+	return binary.AppendUvarint(out, v)
+}
+
+func appendBytesField(out []byte, field int, b []byte) []byte {
+	out = appendTag(out, field, wireBytes)
+	out = binary.AppendUvarint(out, uint64(len(b)))
+	return append(out, b...)
+}
+
+func appendStringField(out []byte, field int, s string) []byte {
+	out = appendTag(out, field, wireBytes)
+	out = binary.AppendUvarint(out, uint64(len(s)))
+	return append(out, s...)
+}
+
+func appendDoubleField(out []byte, field int, f float64) []byte {
+	out = appendTag(out, field, wireFixed64)
+	return binary.LittleEndian.AppendUint64(out, math.Float64bits(f))
+}
+
+func appendFloatField(out []byte, field int, f float32) []byte {
+	out = appendTag(out, field, wireFixed32)
+	return binary.LittleEndian.AppendUint32(out, math.Float32bits(f))
+}
+
+// appendPackedField appends a packed repeated uint32 field.
+func appendPackedField(out []byte, field int, vs []uint32) []byte {
+	var packed []byte
+	for _, v := range vs {
+		packed = binary.AppendUvarint(packed, uint64(v))
+	}
+	return appendBytesField(out, field, packed)
+}
+
+// zigzag encodes a signed integer so that small magnitudes have short varints.
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
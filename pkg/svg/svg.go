@@ -0,0 +1,250 @@
+// Package svg renders H3 cells as standalone SVG images, which is handy for
+// eyeballing the results of grid operations while debugging or in tests.
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+const (
+	// DEFAULT_WIDTH is the default width of the image in pixels.
+	DEFAULT_WIDTH = 800
+	// DEFAULT_STROKE is the default colour of cell outlines.
+	DEFAULT_STROKE = "#333333"
+	// DEFAULT_FILL is the fill colour CellSet uses for its cells.
+	DEFAULT_FILL = "#cfe2f3"
+
+	// padding is the margin in pixels around the cells.
+	padding = 10
+)
+
+// Projection projects a point to planar coordinates, with y growing
+// northwards. Longitudes may be outside [-π, π], as rings crossing the
+// antimeridian are unwrapped before projecting.
+type Projection func(ll h3.LatLng) (x float64, y float64)
+
+// Equirectangular projects longitude and latitude linearly. It is the default
+// projection.
+func Equirectangular(ll h3.LatLng) (float64, float64) {
+	return ll.Longitude(), ll.Latitude()
+}
+
+// WebMercator projects points as web maps do, which keeps cells from looking
+// squashed away from the equator.
+func WebMercator(ll h3.LatLng) (float64, float64) {
+	return ll.Longitude(), math.Asinh(math.Tan(ll.Latitude()))
+}
+
+// Cell is a cell to draw, with an optional fill colour and label. Colours are
+// any SVG colour, e.g. "red" or "#ff0000"; no fill leaves the cell hollow.
+type Cell struct {
+	Cell  h3.Cell
+	Fill  string
+	Label string
+}
+
+// Options control how cells are rendered. Zero values select the defaults.
+type Options struct {
+	// Width is the width of the image in pixels; the height follows from the
+	// extent of the cells.
+	Width int
+	// Projection projects the cells to the image.
+	Projection Projection
+	// Stroke is the colour of cell outlines.
+	Stroke string
+	// LabelIDs labels each cell without a label with its hex id.
+	LabelIDs bool
+}
+
+func (o Options) withDefaults() Options {
+	if o.Width <= 0 {
+		o.Width = DEFAULT_WIDTH
+	}
+	if o.Projection == nil {
+		o.Projection = Equirectangular
+	}
+	if o.Stroke == "" {
+		o.Stroke = DEFAULT_STROKE
+	}
+	return o
+}
+
+// CellSet renders the cells in the set, labelled with their ids, with the
+// default options. It is meant to be quick to call from a test or debugger.
+func CellSet(cs h3.CellSet) (string, error) {
+	var b bytes.Buffer
+	err := Render(&b, CellSetCells(cs, DEFAULT_FILL), Options{LabelIDs: true})
+	return b.String(), err
+}
+
+// CellSetCells returns the cells in the set with the given fill, ordered by
+// cell. Cells from several sets can be combined, with different fills, to
+// compare them.
+func CellSetCells(cs h3.CellSet, fill string) []Cell {
+	cells := make([]Cell, 0, len(cs))
+	for c := range cs {
+		cells = append(cells, Cell{Cell: c, Fill: fill})
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i].Cell < cells[j].Cell })
+	return cells
+}
+
+// ValueCells returns the cells with their values as labels, filled on a scale
+// from white for the smallest value to dark blue for the largest, ordered by
+// cell.
+func ValueCells(values map[h3.Cell]float64) []Cell {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	cells := make([]Cell, 0, len(values))
+	for c, v := range values {
+		t := 1.0
+		if hi > lo {
+			t = (v - lo) / (hi - lo)
+		}
+		// Interpolate from white to #08306b.
+		fill := fmt.Sprintf("#%02x%02x%02x", lerp(255, 0x08, t), lerp(255, 0x30, t), lerp(255, 0x6b, t))
+		cells = append(cells, Cell{Cell: c, Fill: fill, Label: strconv.FormatFloat(v, 'g', 4, 64)})
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i].Cell < cells[j].Cell })
+	return cells
+}
+
+func lerp(from float64, to float64, t float64) int {
+	return int(math.Round(from + (to-from)*t))
+}
+
+// projectedCell is a cell projected to planar coordinates.
+type projectedCell struct {
+	Cell
+	points [][2]float64
+	center [2]float64
+}
+
+// Render writes an SVG image of the cells, drawn in order, to w.
+func Render(w io.Writer, cells []Cell, opts Options) error {
+	opts = opts.withDefaults()
+
+	projected := make([]projectedCell, 0, len(cells))
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	refLng := 0.0
+	for i, c := range cells {
+		boundary, err := c.Cell.Boundary()
+		if err != nil {
+			return fmt.Errorf("error getting boundary for cell %s: %w", c.Cell, err)
+		}
+		center, err := c.Cell.LatLng()
+		if err != nil {
+			return fmt.Errorf("error getting center of cell %s: %w", c.Cell, err)
+		}
+
+		// Keep every cell on the same side of the antimeridian as the first
+		// one, so that neighbors are drawn next to each other.
+		if i == 0 {
+			refLng = center.Longitude()
+		}
+		centerLng := refLng + wrap(center.Longitude()-refLng)
+
+		p := projectedCell{Cell: c}
+		p.center[0], p.center[1] = opts.Projection(h3.LatLng{center.Latitude(), centerLng})
+		for _, v := range boundary {
+			lng := centerLng + wrap(v.Longitude()-centerLng)
+			x, y := opts.Projection(h3.LatLng{v.Latitude(), lng})
+			p.points = append(p.points, [2]float64{x, y})
+
+			minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+		projected = append(projected, p)
+	}
+
+	// Scale the cells to the width, keeping the aspect ratio.
+	width := float64(opts.Width)
+	height := width
+	scale := 0.0
+	if len(projected) > 0 && maxX > minX {
+		scale = (width - 2*padding) / (maxX - minX)
+		height = (maxY-minY)*scale + 2*padding
+	}
+	toImage := func(p [2]float64) (float64, float64) {
+		return (p[0]-minX)*scale + padding, (maxY-p[1])*scale + padding
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		formatFloat(width), formatFloat(height), formatFloat(width), formatFloat(height))
+
+	fmt.Fprintf(&b, `<g stroke="%s" stroke-width="1" stroke-linejoin="round">`+"\n", escape(opts.Stroke))
+	for _, p := range projected {
+		b.WriteString(`<polygon points="`)
+		for i, pt := range p.points {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			x, y := toImage(pt)
+			b.WriteString(formatFloat(x) + "," + formatFloat(y))
+		}
+		fill := p.Fill
+		if fill == "" {
+			fill = "none"
+		}
+		fmt.Fprintf(&b, `" fill="%s"><title>%s</title></polygon>`+"\n", escape(fill), p.Cell.Cell)
+	}
+	b.WriteString("</g>\n")
+
+	b.WriteString(`<g font-family="monospace" text-anchor="middle" dominant-baseline="middle" fill="#000000">` + "\n")
+	for _, p := range projected {
+		label := p.Label
+		if label == "" && opts.LabelIDs {
+			label = p.Cell.Cell.String()
+		}
+		if label == "" {
+			continue
+		}
+
+		// Size the label to fit across the cell.
+		cellMinX, cellMaxX := math.Inf(1), math.Inf(-1)
+		for _, pt := range p.points {
+			cellMinX, cellMaxX = math.Min(cellMinX, pt[0]), math.Max(cellMaxX, pt[0])
+		}
+		fontSize := (cellMaxX - cellMinX) * scale / float64(len(label)) * 1.4
+
+		x, y := toImage(p.center)
+		fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="%s">%s</text>`+"\n",
+			formatFloat(x), formatFloat(y), formatFloat(fontSize), escape(label))
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("</svg>\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// wrap normalizes a longitude difference to [-π, π].
+func wrap(d float64) float64 {
+	return d - 2*math.Pi*math.Round(d/(2*math.Pi))
+}
+
+// formatFloat formats coordinates with enough precision for an image.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+// escape escapes text for use in XML attributes and content.
+func escape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,123 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+// image is the structure of the rendered SVG.
+type image struct {
+	Width    string `xml:"width,attr"`
+	Height   string `xml:"height,attr"`
+	Polygons []struct {
+		Points string `xml:"points,attr"`
+		Fill   string `xml:"fill,attr"`
+		Title  string `xml:"title"`
+	} `xml:"g>polygon"`
+	Texts []struct {
+		Text string `xml:",chardata"`
+	} `xml:"g>text"`
+}
+
+func parseImage(t *testing.T, s string) image {
+	t.Helper()
+	var img image
+	assert.NoError(t, xml.Unmarshal([]byte(s), &img))
+	return img
+}
+
+func TestCellSet(t *testing.T) {
+	disk, err := h3.CellSet{0x872830828ffffff: {}}.GridDisk(1)
+	assert.NoError(t, err)
+
+	got, err := CellSet(disk)
+	assert.NoError(t, err)
+
+	img := parseImage(t, got)
+	assert.Equal(t, "800.00", img.Width)
+	assert.Len(t, img.Polygons, 7)
+	assert.Len(t, img.Texts, 7)
+	for i, c := range CellSetCells(disk, "") {
+		assert.Equal(t, c.Cell.String(), img.Polygons[i].Title)
+		assert.Equal(t, c.Cell.String(), img.Texts[i].Text)
+		assert.Equal(t, DEFAULT_FILL, img.Polygons[i].Fill)
+	}
+
+	// Every point is inside the image.
+	height, err := strconv.ParseFloat(img.Height, 64)
+	assert.NoError(t, err)
+	for _, p := range img.Polygons {
+		for _, pt := range strings.Fields(p.Points) {
+			x, y, ok := strings.Cut(pt, ",")
+			assert.True(t, ok)
+			fx, err := strconv.ParseFloat(x, 64)
+			assert.NoError(t, err)
+			fy, err := strconv.ParseFloat(y, 64)
+			assert.NoError(t, err)
+			assert.True(t, fx >= 0 && fx <= 800 && fy >= 0 && fy <= height, "point %s outside the image", pt)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("labels and fills", func(t *testing.T) {
+		cells := []Cell{
+			{Cell: 0x872830828ffffff, Fill: "red", Label: "a<b"},
+			{Cell: 0x87283082affffff},
+		}
+
+		var b bytes.Buffer
+		assert.NoError(t, Render(&b, cells, Options{Width: 200, Projection: WebMercator, Stroke: "blue"}))
+		assert.Contains(t, b.String(), `stroke="blue"`)
+
+		img := parseImage(t, b.String())
+		assert.Equal(t, "200.00", img.Width)
+		assert.Len(t, img.Polygons, 2)
+		assert.Equal(t, "red", img.Polygons[0].Fill)
+		assert.Equal(t, "none", img.Polygons[1].Fill)
+		assert.Len(t, img.Texts, 1)
+		assert.Equal(t, "a<b", img.Texts[0].Text)
+	})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		// The cells on either side of the antimeridian are drawn next to each
+		// other, so the image isn't stretched across the whole world.
+		c, err := h3.NewCellFromLatLng(h3.NewLatLng(0, 180), 5)
+		assert.NoError(t, err)
+		disk, err := h3.CellSet{c: {}}.GridDisk(2)
+		assert.NoError(t, err)
+
+		got, err := CellSet(disk)
+		assert.NoError(t, err)
+		img := parseImage(t, got)
+		height, err := strconv.ParseFloat(img.Height, 64)
+		assert.NoError(t, err)
+		assert.Greater(t, height, 400.0)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, Render(&b, nil, Options{}))
+		img := parseImage(t, b.String())
+		assert.Empty(t, img.Polygons)
+	})
+
+	t.Run("invalid cell", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.Error(t, Render(&b, []Cell{{Cell: 0x7fffffffffffffff}}, Options{}))
+	})
+}
+
+func TestValueCells(t *testing.T) {
+	cells := ValueCells(map[h3.Cell]float64{0x872830828ffffff: 1, 0x87283082affffff: 3, 0x872830958ffffff: 2})
+	assert.Len(t, cells, 3)
+	assert.Equal(t, Cell{Cell: 0x872830828ffffff, Fill: "#ffffff", Label: "1"}, cells[0])
+	assert.Equal(t, Cell{Cell: 0x87283082affffff, Fill: "#08306b", Label: "3"}, cells[1])
+	assert.Equal(t, "2", cells[2].Label)
+}
@@ -0,0 +1,311 @@
+// Package wkb converts H3 cell boundaries, cell set outlines and polygons to
+// and from Well-Known Binary (WKB), including the Extended WKB (EWKB) used by
+// PostGIS to carry a spatial reference id.
+//
+// Coordinates are written as longitude, latitude in degrees, and rings are
+// closed by repeating their first vertex. Polygons read from WKB are returned
+// as rings of h3.LatLng without the closing vertex, matching h3.Cell.Boundary
+// and h3.CellSet.Outline.
+package wkb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+	"github.com/ziprecruiter/h3-go/pkg/internal/geom"
+)
+
+const (
+	// SRID_WGS84 is the spatial reference id of WGS 84 longitude/latitude
+	// coordinates, the only one polygons can be read in.
+	SRID_WGS84 = 4326
+
+	// Byte order markers.
+	bigEndian    = 0
+	littleEndian = 1
+
+	// Geometry type codes.
+	typePolygon      = 3
+	typeMultiPolygon = 6
+
+	// EWKB flags in the high bits of the geometry type.
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+var (
+	// ErrUnsupportedType is returned for WKB geometries other than Polygon
+	// and MultiPolygon.
+	ErrUnsupportedType = fmt.Errorf("unsupported WKB geometry type")
+	// ErrUnsupportedSRID is returned for EWKB with a SRID other than
+	// SRID_WGS84.
+	ErrUnsupportedSRID = fmt.Errorf("unsupported SRID")
+	// ErrInvalidGeometry is returned for malformed WKB.
+	ErrInvalidGeometry = fmt.Errorf("invalid WKB geometry")
+)
+
+// Options control how geometries are encoded.
+type Options struct {
+	// ByteOrder is the byte order to encode with, binary.LittleEndian or
+	// binary.BigEndian. It defaults to little endian, which is what most
+	// databases produce.
+	ByteOrder binary.AppendByteOrder
+	// EWKB is whether to encode Extended WKB, tagged with the WGS 84 spatial
+	// reference id (SRID 4326).
+	EWKB bool
+}
+
+func (o Options) byteOrder() (binary.AppendByteOrder, byte) {
+	if o.ByteOrder == nil {
+		return binary.LittleEndian, littleEndian
+	}
+	if o.ByteOrder.AppendUint16(nil, 1)[0] == 1 {
+		return o.ByteOrder, littleEndian
+	}
+	return o.ByteOrder, bigEndian
+}
+
+// Polygon returns the WKB Polygon of a list of rings: the outer ring followed
+// by any holes. Empty holes are left out, and a polygon with an empty outer
+// ring is written as an empty Polygon.
+func Polygon(rings [][]h3.LatLng, opts Options) []byte {
+	return appendPolygon(nil, rings, opts, opts.EWKB)
+}
+
+// MultiPolygon returns the WKB MultiPolygon of a list of polygons, each a list
+// of rings as for Polygon. Empty polygons are left out.
+func MultiPolygon(polygons [][][]h3.LatLng, opts Options) []byte {
+	polygons = geom.NonEmptyPolygons(polygons)
+	out := appendHeader(nil, typeMultiPolygon, opts, opts.EWKB)
+	order, _ := opts.byteOrder()
+	out = order.AppendUint32(out, uint32(len(polygons)))
+
+	// The polygons are full geometries, but only the outer one carries a SRID.
+	for _, rings := range polygons {
+		out = appendPolygon(out, rings, opts, false)
+	}
+	return out
+}
+
+// Cell returns the WKB Polygon of the boundary of the cell.
+func Cell(c h3.Cell, opts Options) ([]byte, error) {
+	boundary, err := c.Boundary()
+	if err != nil {
+		return nil, fmt.Errorf("error getting boundary for cell %s: %w", c, err)
+	}
+	return Polygon([][]h3.LatLng{boundary}, opts), nil
+}
+
+// CellSetOutline returns the WKB MultiPolygon of the outline of the cells in
+// the set.
+func CellSetOutline(cs h3.CellSet, opts Options) ([]byte, error) {
+	polygons, err := cs.Outline()
+	if err != nil {
+		return nil, fmt.Errorf("error getting outline of cell set: %w", err)
+	}
+	return MultiPolygon(polygons, opts), nil
+}
+
+func appendHeader(out []byte, typ uint32, opts Options, srid bool) []byte {
+	order, marker := opts.byteOrder()
+	out = append(out, marker)
+	if srid {
+		out = order.AppendUint32(out, typ|ewkbSRID)
+		return order.AppendUint32(out, SRID_WGS84)
+	}
+	return order.AppendUint32(out, typ)
+}
+
+func appendPolygon(out []byte, rings [][]h3.LatLng, opts Options, srid bool) []byte {
+	rings = geom.NonEmptyRings(rings)
+	order, _ := opts.byteOrder()
+	out = appendHeader(out, typePolygon, opts, srid)
+	out = order.AppendUint32(out, uint32(len(rings)))
+	for _, ring := range rings {
+		out = order.AppendUint32(out, uint32(len(ring)+1))
+		for i := 0; i <= len(ring); i++ {
+			ll := ring[i%len(ring)]
+			out = order.AppendUint64(out, math.Float64bits(ll.LongitudeDegrees()))
+			out = order.AppendUint64(out, math.Float64bits(ll.LatitudeDegrees()))
+		}
+	}
+	return out
+}
+
+// ParsePolygons reads the polygons of a WKB or EWKB Polygon or MultiPolygon in
+// either byte order. Z and M coordinates are ignored, and EWKB must have no
+// SRID or SRID 4326. A Polygon is returned as a single polygon. Empty
+// polygons are left out, as they are when writing.
+func ParsePolygons(data []byte) ([][][]h3.LatLng, error) {
+	r := &reader{data: data}
+
+	typ, dims, err := r.header(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var polygons [][][]h3.LatLng
+	switch typ {
+	case typePolygon:
+		rings, err := r.polygon(dims)
+		if err != nil {
+			return nil, err
+		}
+		if rings != nil {
+			polygons = [][][]h3.LatLng{rings}
+		}
+	case typeMultiPolygon:
+		n, err := r.count(1 + 4 + 4)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			typ, dims, err := r.header(false)
+			if err != nil {
+				return nil, err
+			}
+			if typ != typePolygon {
+				return nil, fmt.Errorf("%w: multipolygon contains geometry type %d", ErrInvalidGeometry, typ)
+			}
+
+			rings, err := r.polygon(dims)
+			if err != nil {
+				return nil, err
+			}
+			// Empty polygons are skipped, as MultiPolygon leaves them out.
+			if rings != nil {
+				polygons = append(polygons, rings)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedType, typ)
+	}
+
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidGeometry, len(r.data)-r.pos)
+	}
+
+	return polygons, nil
+}
+
+// reader decodes WKB. Every geometry has its own byte order marker, so the
+// byte order changes as it goes.
+type reader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *reader) uint32() (uint32, error) {
+	if len(r.data)-r.pos < 4 {
+		return 0, fmt.Errorf("%w: unexpected end of data", ErrInvalidGeometry)
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// count reads an element count, checking that there is enough data left for
+// that many elements of at least minSize bytes, so that corrupt counts don't
+// cause huge allocations.
+func (r *reader) count(minSize int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if int64(n)*int64(minSize) > int64(len(r.data)-r.pos) {
+		return 0, fmt.Errorf("%w: count %d exceeds the data", ErrInvalidGeometry, n)
+	}
+	return int(n), nil
+}
+
+// header reads the byte order, geometry type and optional SRID of a geometry,
+// and returns the base geometry type and the number of coordinates per point.
+// Only the outermost geometry may carry a SRID.
+func (r *reader) header(top bool) (uint32, int, error) {
+	if r.pos >= len(r.data) {
+		return 0, 0, fmt.Errorf("%w: unexpected end of data", ErrInvalidGeometry)
+	}
+	switch r.data[r.pos] {
+	case bigEndian:
+		r.order = binary.BigEndian
+	case littleEndian:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, fmt.Errorf("%w: bad byte order %d", ErrInvalidGeometry, r.data[r.pos])
+	}
+	r.pos++
+
+	typ, err := r.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if typ&ewkbSRID != 0 {
+		if !top {
+			return 0, 0, fmt.Errorf("%w: SRID on a nested geometry", ErrInvalidGeometry)
+		}
+		srid, err := r.uint32()
+		if err != nil {
+			return 0, 0, err
+		}
+		if srid != SRID_WGS84 {
+			return 0, 0, fmt.Errorf("%w: %d", ErrUnsupportedSRID, srid)
+		}
+	}
+
+	// Z and M are either EWKB flags or ISO thousands (1000 Z, 2000 M, 3000 ZM).
+	dims := 2
+	if typ&ewkbZ != 0 {
+		dims++
+	}
+	if typ&ewkbM != 0 {
+		dims++
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+	switch typ / 1000 {
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	}
+
+	return typ % 1000, dims, nil
+}
+
+// polygon reads the rings of a polygon, returning nil if it has none.
+func (r *reader) polygon(dims int) ([][]h3.LatLng, error) {
+	n, err := r.count(4)
+	if err != nil {
+		return nil, err
+	}
+
+	var rings [][]h3.LatLng
+	for i := 0; i < n; i++ {
+		numPoints, err := r.count(dims * 8)
+		if err != nil {
+			return nil, err
+		}
+
+		ring := make([]h3.LatLng, numPoints)
+		for j := range ring {
+			lng := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+			lat := math.Float64frombits(r.order.Uint64(r.data[r.pos+8:]))
+			ring[j] = h3.NewLatLng(lat, lng)
+			r.pos += dims * 8
+		}
+
+		if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+			ring = ring[:len(ring)-1]
+		}
+		if len(ring) < 3 {
+			return nil, fmt.Errorf("%w: ring has %d distinct vertices", ErrInvalidGeometry, len(ring))
+		}
+		rings = append(rings, ring)
+	}
+
+	return rings, nil
+}
@@ -0,0 +1,264 @@
+package wkb
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+var triangle = [][]h3.LatLng{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// assertPolygonsInDelta asserts that the polygons have the same shape, with
+// vertices within a tiny delta, as converting to degrees and back rounds.
+func assertPolygonsInDelta(t *testing.T, want [][][]h3.LatLng, got [][][]h3.LatLng) {
+	t.Helper()
+	if !assert.Len(t, got, len(want)) {
+		return
+	}
+	for i := range want {
+		if !assert.Len(t, got[i], len(want[i])) {
+			return
+		}
+		for j := range want[i] {
+			if !assert.Len(t, got[i][j], len(want[i][j])) {
+				return
+			}
+			for k, ll := range want[i][j] {
+				assert.InDelta(t, ll.Latitude(), got[i][j][k].Latitude(), 1e-12)
+				assert.InDelta(t, ll.Longitude(), got[i][j][k].Longitude(), 1e-12)
+			}
+		}
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "little endian",
+			opts: Options{},
+			want: `01 03000000 01000000 04000000
+				0000000000000000 0000000000000000
+				000000000000f03f 0000000000000000
+				000000000000f03f 000000000000f03f
+				0000000000000000 0000000000000000`,
+		},
+		{
+			name: "big endian",
+			opts: Options{ByteOrder: binary.BigEndian},
+			want: `00 00000003 00000001 00000004
+				0000000000000000 0000000000000000
+				3ff0000000000000 0000000000000000
+				3ff0000000000000 3ff0000000000000
+				0000000000000000 0000000000000000`,
+		},
+		{
+			name: "ewkb",
+			opts: Options{ByteOrder: binary.LittleEndian, EWKB: true},
+			want: `01 03000020 e6100000 01000000 04000000
+				0000000000000000 0000000000000000
+				000000000000f03f 0000000000000000
+				000000000000f03f 000000000000f03f
+				0000000000000000 0000000000000000`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Polygon(triangle, tt.opts)
+			assert.Equal(t, mustDecodeHex(tt.want), got)
+
+			polygons, err := ParsePolygons(got)
+			assert.NoError(t, err)
+			assert.Equal(t, [][][]h3.LatLng{triangle}, polygons)
+		})
+	}
+
+	t.Run("empty rings", func(t *testing.T) {
+		// Empty holes are left out.
+		got := Polygon([][]h3.LatLng{triangle[0], {}}, Options{})
+		assert.Equal(t, Polygon(triangle, Options{}), got)
+
+		// A polygon with an empty outer ring is empty.
+		for _, rings := range [][][]h3.LatLng{nil, {{}}, {{}, triangle[0]}} {
+			got := Polygon(rings, Options{})
+			assert.Equal(t, mustDecodeHex("01 03000000 00000000"), got)
+		}
+	})
+}
+
+func TestMultiPolygon(t *testing.T) {
+	for _, opts := range []Options{{}, {ByteOrder: binary.BigEndian}, {EWKB: true}, {ByteOrder: binary.BigEndian, EWKB: true}} {
+		got := MultiPolygon([][][]h3.LatLng{triangle, triangle}, opts)
+
+		polygons, err := ParsePolygons(got)
+		assert.NoError(t, err)
+		assert.Equal(t, [][][]h3.LatLng{triangle, triangle}, polygons)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		got := MultiPolygon(nil, Options{})
+		assert.Equal(t, mustDecodeHex("01 06000000 00000000"), got)
+
+		polygons, err := ParsePolygons(got)
+		assert.NoError(t, err)
+		assert.Empty(t, polygons)
+	})
+
+	t.Run("empty polygons", func(t *testing.T) {
+		got := MultiPolygon([][][]h3.LatLng{triangle, {}, {{}}}, Options{})
+		assert.Equal(t, MultiPolygon([][][]h3.LatLng{triangle}, Options{}), got)
+
+		got = MultiPolygon([][][]h3.LatLng{{}, {{}}}, Options{})
+		assert.Equal(t, mustDecodeHex("01 06000000 00000000"), got)
+	})
+}
+
+func TestCell(t *testing.T) {
+	c := h3.Cell(0x85283473fffffff)
+	got, err := Cell(c, Options{EWKB: true})
+	assert.NoError(t, err)
+
+	boundary, err := c.Boundary()
+	assert.NoError(t, err)
+	polygons, err := ParsePolygons(got)
+	assert.NoError(t, err)
+	assertPolygonsInDelta(t, [][][]h3.LatLng{{boundary}}, polygons)
+
+	_, err = Cell(0x7fffffffffffffff, Options{})
+	assert.Error(t, err)
+}
+
+func TestCellSetOutline(t *testing.T) {
+	disk, err := h3.CellSet{0x872830828ffffff: {}}.GridDisk(2)
+	assert.NoError(t, err)
+	delete(disk, 0x872830828ffffff)
+
+	got, err := CellSetOutline(disk, Options{ByteOrder: binary.BigEndian})
+	assert.NoError(t, err)
+
+	want, err := disk.Outline()
+	assert.NoError(t, err)
+	polygons, err := ParsePolygons(got)
+	assert.NoError(t, err)
+	assertPolygonsInDelta(t, want, polygons)
+}
+
+func TestParsePolygons(t *testing.T) {
+	tests := []struct {
+		name    string
+		wkb     string
+		want    [][][]h3.LatLng
+		wantErr error
+	}{
+		{
+			name: "iso z coordinates",
+			wkb: `01 eb030000 01000000 03000000
+				0000000000000000 0000000000000000 0000000000001440
+				000000000000f03f 0000000000000000 0000000000001440
+				000000000000f03f 000000000000f03f 0000000000001440`,
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "ewkb zm coordinates",
+			wkb: `00 e0000003 000010e6 00000001 00000003
+				0000000000000000 0000000000000000 4014000000000000 4014000000000000
+				3ff0000000000000 0000000000000000 4014000000000000 4014000000000000
+				3ff0000000000000 3ff0000000000000 4014000000000000 4014000000000000`,
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "mixed byte orders",
+			wkb: `00 00000006 00000001
+				01 03000000 01000000 03000000
+				0000000000000000 0000000000000000
+				000000000000f03f 0000000000000000
+				000000000000f03f 000000000000f03f`,
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "empty polygons in multipolygon",
+			wkb: `01 06000000 03000000
+				01 03000000 00000000
+				01 03000000 01000000 03000000
+				0000000000000000 0000000000000000
+				000000000000f03f 0000000000000000
+				000000000000f03f 000000000000f03f
+				01 03000000 00000000`,
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name:    "other srid",
+			wkb:     `01 03000020 110f0000 00000000`,
+			wantErr: ErrUnsupportedSRID,
+		},
+		{
+			name:    "unsupported type",
+			wkb:     `01 01000000 0000000000000000 0000000000000000`,
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "bad byte order",
+			wkb:     `02 03000000 00000000`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "truncated",
+			wkb:     `01 03000000 01000000 04000000 0000000000000000`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "huge count",
+			wkb:     `01 06000000 ffffffff`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "trailing bytes",
+			wkb:     `01 03000000 00000000 00`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name: "degenerate ring",
+			wkb: `01 03000000 01000000 03000000
+				0000000000000000 0000000000000000
+				000000000000f03f 0000000000000000
+				0000000000000000 0000000000000000`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "nested srid",
+			wkb:     `01 06000000 01000000 01 03000020 e6100000 00000000`,
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "nested non-polygon",
+			wkb:     `01 06000000 01000000 01 01000000 0000000000000000 0000000000000000`,
+			wantErr: ErrInvalidGeometry,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolygons(mustDecodeHex(tt.wkb))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+// Package wkt converts H3 cell boundaries, cell set outlines and polygons to
+// and from Well-Known Text (WKT).
+//
+// Coordinates are written as "longitude latitude" in degrees, and rings are
+// closed by repeating their first vertex. Polygons read from WKT are returned
+// as rings of h3.LatLng without the closing vertex, matching h3.Cell.Boundary
+// and h3.CellSet.Outline.
+package wkt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+	"github.com/ziprecruiter/h3-go/pkg/internal/geom"
+)
+
+// SRID_WGS84 is the spatial reference id of WGS 84 longitude/latitude
+// coordinates, the only one polygons can be read in.
+const SRID_WGS84 = 4326
+
+var (
+	// ErrUnsupportedType is returned for WKT geometries other than POLYGON
+	// and MULTIPOLYGON.
+	ErrUnsupportedType = fmt.Errorf("unsupported WKT geometry type")
+	// ErrUnsupportedSRID is returned for Extended WKT with a SRID other than
+	// SRID_WGS84.
+	ErrUnsupportedSRID = fmt.Errorf("unsupported SRID")
+	// ErrInvalidGeometry is returned for malformed WKT.
+	ErrInvalidGeometry = fmt.Errorf("invalid WKT geometry")
+)
+
+// Polygon returns the WKT POLYGON of a list of rings: the outer ring followed
+// by any holes. Empty holes are left out, and a polygon with an empty outer
+// ring is written as POLYGON EMPTY.
+func Polygon(rings [][]h3.LatLng) string {
+	var b strings.Builder
+	b.WriteString("POLYGON ")
+	writeRings(&b, rings)
+	return b.String()
+}
+
+// MultiPolygon returns the WKT MULTIPOLYGON of a list of polygons, each a list
+// of rings as for Polygon. Empty polygons are left out.
+func MultiPolygon(polygons [][][]h3.LatLng) string {
+	polygons = geom.NonEmptyPolygons(polygons)
+
+	var b strings.Builder
+	b.WriteString("MULTIPOLYGON ")
+	if len(polygons) == 0 {
+		b.WriteString("EMPTY")
+		return b.String()
+	}
+
+	b.WriteByte('(')
+	for i, rings := range polygons {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeRings(&b, rings)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// EWKT prefixes WKT with the WGS 84 spatial reference id, as PostGIS expects
+// for Extended WKT.
+func EWKT(wkt string) string {
+	return "SRID=" + strconv.Itoa(SRID_WGS84) + ";" + wkt
+}
+
+// Cell returns the WKT POLYGON of the boundary of the cell.
+func Cell(c h3.Cell) (string, error) {
+	boundary, err := c.Boundary()
+	if err != nil {
+		return "", fmt.Errorf("error getting boundary for cell %s: %w", c, err)
+	}
+	return Polygon([][]h3.LatLng{boundary}), nil
+}
+
+// CellSetOutline returns the WKT MULTIPOLYGON of the outline of the cells in
+// the set.
+func CellSetOutline(cs h3.CellSet) (string, error) {
+	polygons, err := cs.Outline()
+	if err != nil {
+		return "", fmt.Errorf("error getting outline of cell set: %w", err)
+	}
+	return MultiPolygon(polygons), nil
+}
+
+func writeRings(b *strings.Builder, rings [][]h3.LatLng) {
+	rings = geom.NonEmptyRings(rings)
+	if len(rings) == 0 {
+		b.WriteString("EMPTY")
+		return
+	}
+
+	b.WriteByte('(')
+	for i, ring := range rings {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := 0; j <= len(ring); j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			ll := ring[j%len(ring)]
+			b.WriteString(strconv.FormatFloat(ll.LongitudeDegrees(), 'f', -1, 64))
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(ll.LatitudeDegrees(), 'f', -1, 64))
+		}
+		b.WriteByte(')')
+	}
+	b.WriteByte(')')
+}
+
+// ParsePolygons reads the polygons of a WKT POLYGON or MULTIPOLYGON, which may
+// have Z and M coordinates (they are ignored), and may be Extended WKT with a
+// SRID=4326 prefix. A POLYGON is returned as a single polygon. Empty
+// polygons are left out, as they are when writing.
+func ParsePolygons(wkt string) ([][][]h3.LatLng, error) {
+	p := &parser{s: wkt}
+
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(wkt)), "SRID=") {
+		header, rest, ok := strings.Cut(strings.TrimSpace(wkt), ";")
+		if !ok {
+			return nil, fmt.Errorf("%w: missing ';' after SRID", ErrInvalidGeometry)
+		}
+		srid, err := strconv.Atoi(header[len("SRID="):])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidGeometry, err)
+		}
+		if srid != SRID_WGS84 {
+			return nil, fmt.Errorf("%w: %d", ErrUnsupportedSRID, srid)
+		}
+		p.s = rest
+	}
+
+	typ := p.word()
+	dims := 2
+	switch p.peekWord() {
+	case "Z", "M":
+		p.word()
+		dims = 3
+	case "ZM":
+		p.word()
+		dims = 4
+	}
+
+	var polygons [][][]h3.LatLng
+	var err error
+	switch typ {
+	case "POLYGON":
+		var rings [][]h3.LatLng
+		rings, err = p.polygon(dims)
+		if rings != nil {
+			polygons = [][][]h3.LatLng{rings}
+		}
+	case "MULTIPOLYGON":
+		// Empty polygons are skipped, as MultiPolygon leaves them out.
+		err = p.list(func() error {
+			rings, err := p.polygon(dims)
+			if rings != nil {
+				polygons = append(polygons, rings)
+			}
+			return err
+		})
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, fmt.Errorf("%w: unexpected %q at offset %d", ErrInvalidGeometry, p.s[p.pos:], p.pos)
+	}
+
+	return polygons, nil
+}
+
+// parser is a recursive descent parser for the subset of WKT that describes
+// polygons.
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// peekWord returns the next word in upper case without consuming it.
+func (p *parser) peekWord() string {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.s) && unicode.IsLetter(rune(p.s[end])) {
+		end++
+	}
+	return strings.ToUpper(p.s[p.pos:end])
+}
+
+// word consumes the next word and returns it in upper case.
+func (p *parser) word() string {
+	w := p.peekWord()
+	p.pos += len(w)
+	return w
+}
+
+// expect consumes the given byte, or returns an error if it isn't next.
+func (p *parser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) || p.s[p.pos] != c {
+		return fmt.Errorf("%w: expected %q at offset %d", ErrInvalidGeometry, c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// list parses either EMPTY, or a parenthesized, comma separated list whose
+// elements are parsed by element.
+func (p *parser) list(element func() error) error {
+	if p.peekWord() == "EMPTY" {
+		p.word()
+		return nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		if err := element(); err != nil {
+			return err
+		}
+
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return p.expect(')')
+	}
+}
+
+// polygon parses the rings of a polygon, returning nil if it's EMPTY.
+func (p *parser) polygon(dims int) ([][]h3.LatLng, error) {
+	var rings [][]h3.LatLng
+	err := p.list(func() error {
+		ring, err := p.ring(dims)
+		rings = append(rings, ring)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rings, nil
+}
+
+// ring parses the vertices of a ring, dropping the closing vertex.
+func (p *parser) ring(dims int) ([]h3.LatLng, error) {
+	var ring []h3.LatLng
+	err := p.list(func() error {
+		var coords [4]float64
+		for i := 0; i < dims; i++ {
+			var err error
+			coords[i], err = p.number()
+			if err != nil {
+				return err
+			}
+		}
+		ring = append(ring, h3.NewLatLng(coords[1], coords[0]))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return nil, fmt.Errorf("%w: ring has %d distinct vertices", ErrInvalidGeometry, len(ring))
+	}
+
+	return ring, nil
+}
+
+func (p *parser) number() (float64, error) {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[end]) >= 0 {
+		end++
+	}
+
+	f, err := strconv.ParseFloat(p.s[p.pos:end], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad number at offset %d: %v", ErrInvalidGeometry, p.pos, err)
+	}
+	p.pos = end
+	return f, nil
+}
@@ -0,0 +1,187 @@
+package wkt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ziprecruiter/h3-go/pkg/h3"
+)
+
+// assertPolygonsInDelta asserts that the polygons have the same shape, with
+// vertices within a tiny delta, as converting to degrees and back rounds.
+func assertPolygonsInDelta(t *testing.T, want [][][]h3.LatLng, got [][][]h3.LatLng) {
+	t.Helper()
+	if !assert.Len(t, got, len(want)) {
+		return
+	}
+	for i := range want {
+		if !assert.Len(t, got[i], len(want[i])) {
+			return
+		}
+		for j := range want[i] {
+			if !assert.Len(t, got[i][j], len(want[i][j])) {
+				return
+			}
+			for k, ll := range want[i][j] {
+				assert.InDelta(t, ll.Latitude(), got[i][j][k].Latitude(), 1e-12)
+				assert.InDelta(t, ll.Longitude(), got[i][j][k].Longitude(), 1e-12)
+			}
+		}
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	rings := [][]h3.LatLng{
+		{h3.NewLatLng(0, 0), h3.NewLatLng(0, 10), h3.NewLatLng(10, 10), h3.NewLatLng(10, 0)},
+		{h3.NewLatLng(2, 2), h3.NewLatLng(8, 2), h3.NewLatLng(8, 8)},
+	}
+	assert.Equal(t, "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 2 2))", Polygon(rings))
+	assert.Equal(t, "POLYGON EMPTY", Polygon(nil))
+
+	// Empty rings are left out rather than written without a closing vertex.
+	assert.Equal(t, "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))", Polygon([][]h3.LatLng{rings[0], {}}))
+	assert.Equal(t, "POLYGON EMPTY", Polygon([][]h3.LatLng{{}}))
+	assert.Equal(t, "POLYGON EMPTY", Polygon([][]h3.LatLng{{}, rings[1]}))
+}
+
+func TestMultiPolygon(t *testing.T) {
+	polygons := [][][]h3.LatLng{
+		{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}},
+		{{h3.NewLatLng(2, 2), h3.NewLatLng(2, 8), h3.NewLatLng(8, 8)}},
+	}
+	assert.Equal(t, "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((2 2, 8 2, 8 8, 2 2)))", MultiPolygon(polygons))
+	assert.Equal(t, "MULTIPOLYGON EMPTY", MultiPolygon(nil))
+	assert.Equal(t, "SRID=4326;MULTIPOLYGON EMPTY", EWKT(MultiPolygon(nil)))
+
+	// Empty polygons are left out.
+	assert.Equal(t, "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)))", MultiPolygon([][][]h3.LatLng{polygons[0], {}, {{}}}))
+	assert.Equal(t, "MULTIPOLYGON EMPTY", MultiPolygon([][][]h3.LatLng{{}, {{}}}))
+}
+
+func TestCell(t *testing.T) {
+	c := h3.Cell(0x85283473fffffff)
+	got, err := Cell(c)
+	assert.NoError(t, err)
+
+	boundary, err := c.Boundary()
+	assert.NoError(t, err)
+	polygons, err := ParsePolygons(got)
+	assert.NoError(t, err)
+	assertPolygonsInDelta(t, [][][]h3.LatLng{{boundary}}, polygons)
+
+	_, err = Cell(0x7fffffffffffffff)
+	assert.Error(t, err)
+}
+
+func TestCellSetOutline(t *testing.T) {
+	disk, err := h3.CellSet{0x872830828ffffff: {}}.GridDisk(2)
+	assert.NoError(t, err)
+	delete(disk, 0x872830828ffffff)
+
+	got, err := CellSetOutline(disk)
+	assert.NoError(t, err)
+
+	want, err := disk.Outline()
+	assert.NoError(t, err)
+	polygons, err := ParsePolygons(got)
+	assert.NoError(t, err)
+	assertPolygonsInDelta(t, want, polygons)
+}
+
+func TestParsePolygons(t *testing.T) {
+	triangle := [][]h3.LatLng{{h3.NewLatLng(0, 0), h3.NewLatLng(0, 1), h3.NewLatLng(1, 1)}}
+
+	tests := []struct {
+		name    string
+		wkt     string
+		want    [][][]h3.LatLng
+		wantErr error
+	}{
+		{
+			name: "polygon",
+			wkt:  "POLYGON ((0 0, 1 0, 1 1, 0 0))",
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "lower case and odd spacing",
+			wkt:  "  polygon((0 0,1 0 ,1 1,0 0 ) )  ",
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "unclosed ring",
+			wkt:  "POLYGON ((0 0, 1 0, 1 1))",
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "scientific notation",
+			wkt:  "POLYGON ((0e0 0, 1E0 0, 1 1.0e+0, 0 0))",
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "z and m coordinates",
+			wkt:  "POLYGON ZM ((0 0 5 6, 1 0 5 6, 1 1 5 6, 0 0 5 6))",
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "ewkt",
+			wkt:  "SRID=4326;MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((0 0, 1 0, 1 1, 0 0)))",
+			want: [][][]h3.LatLng{triangle, triangle},
+		},
+		{
+			name: "empty",
+			wkt:  "MULTIPOLYGON EMPTY",
+			want: nil,
+		},
+		{
+			name:    "other srid",
+			wkt:     "SRID=3857;POLYGON ((0 0, 1 0, 1 1, 0 0))",
+			wantErr: ErrUnsupportedSRID,
+		},
+		{
+			name:    "unsupported type",
+			wkt:     "LINESTRING (0 0, 1 1)",
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "degenerate ring",
+			wkt:     "POLYGON ((0 0, 1 0, 0 0))",
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "unbalanced parentheses",
+			wkt:     "POLYGON ((0 0, 1 0, 1 1, 0 0)",
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "trailing text",
+			wkt:     "POLYGON ((0 0, 1 0, 1 1, 0 0)) x",
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name:    "bad number",
+			wkt:     "POLYGON ((0 0, 1 x, 1 1, 0 0))",
+			wantErr: ErrInvalidGeometry,
+		},
+		{
+			name: "empty polygons in multipolygon",
+			wkt:  "MULTIPOLYGON (EMPTY, ((0 0, 1 0, 1 1, 0 0)), EMPTY)",
+			want: [][][]h3.LatLng{triangle},
+		},
+		{
+			name: "only empty polygons in multipolygon",
+			wkt:  "MULTIPOLYGON (EMPTY)",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolygons(tt.wkt)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}